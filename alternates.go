@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// readAlternateMap reads a file of duplicate videos. Each line holds a video
+// ID followed by the IDs or URLs of its duplicates, separated by spaces.
+// Blank lines and lines starting with # are skipped.
+func readAlternateMap(path string) (yttranscript.AlternateMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	alternates := make(yttranscript.AlternateMap)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		videoID := videoIDArg(fields[0])
+		for _, field := range fields[1:] {
+			alternates[videoID] = append(alternates[videoID], videoIDArg(field))
+		}
+	}
+	return alternates, scanner.Err()
+}
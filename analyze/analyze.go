@@ -0,0 +1,23 @@
+// Package analyze derives editorial signals, such as keywords and highlight
+// candidates, from transcripts.
+package analyze
+
+import (
+	"strings"
+	"unicode"
+)
+
+// terms splits text into lowercase letter runs, as index.Tokenize does,
+// dropping numbers, stopwords and single letters.
+func terms(text string) []string {
+	var out []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if len([]rune(word)) < 2 || stopwords[word] {
+			continue
+		}
+		out = append(out, word)
+	}
+	return out
+}
@@ -0,0 +1,105 @@
+package analyze
+
+import (
+	"strings"
+	"unicode"
+
+	"yt-transcript/yttranscript"
+)
+
+// defaultContextWords is the context given on each side of a match when
+// ConcordanceOptions.Context is not set.
+const defaultContextWords = 5
+
+// ConcordanceOptions configures Concordance.
+type ConcordanceOptions struct {
+	Context int // Words of context on each side of a match. Defaults to 5.
+	// MatchCase requires matches to have the case of the query. Punctuation
+	// is ignored either way.
+	MatchCase bool
+}
+
+// ConcordanceLine is a keyword-in-context line: one match of the query with
+// the words around it, as spoken.
+type ConcordanceLine struct {
+	VideoID string  `json:"video_id"`
+	Start   float64 `json:"start"` // Start time in seconds of the segment the match begins in.
+	Left    string  `json:"left"`
+	Match   string  `json:"match"`
+	Right   string  `json:"right"`
+}
+
+// concordanceWord is a word of a transcript as spoken, with the key it is
+// matched by and the segment it belongs to.
+type concordanceWord struct {
+	text  string
+	key   string
+	start float64
+}
+
+// Concordance lists every occurrence of query in the transcripts with the
+// words around it, in transcript order. The query is one or more words;
+// "*" stands for any single word, so "never * give" finds "never gonna
+// give". Context runs across caption lines but not across transcripts.
+func Concordance(transcripts []*yttranscript.Transcript, query string, opts ConcordanceOptions) []ConcordanceLine {
+	if opts.Context <= 0 {
+		opts.Context = defaultContextWords
+	}
+	var pattern []string
+	for _, word := range strings.Fields(query) {
+		if word == "*" {
+			pattern = append(pattern, word)
+		} else if key := concordanceKey(word, opts.MatchCase); key != "" {
+			pattern = append(pattern, key)
+		}
+	}
+	if len(pattern) == 0 {
+		return nil
+	}
+
+	var lines []ConcordanceLine
+	for _, transcript := range transcripts {
+		var words []concordanceWord
+		for _, text := range transcript.Texts {
+			for _, word := range strings.Fields(text.Content) {
+				words = append(words, concordanceWord{text: word, key: concordanceKey(word, opts.MatchCase), start: text.Start})
+			}
+		}
+
+	search:
+		for i := 0; i+len(pattern) <= len(words); i++ {
+			for j, key := range pattern {
+				if words[i+j].key == "" || key != "*" && words[i+j].key != key {
+					continue search
+				}
+			}
+			end := i + len(pattern)
+			lines = append(lines, ConcordanceLine{
+				VideoID: transcript.VideoID,
+				Start:   words[i].start,
+				Left:    joinWords(words[max(0, i-opts.Context):i]),
+				Match:   joinWords(words[i:end]),
+				Right:   joinWords(words[end:min(len(words), end+opts.Context)]),
+			})
+		}
+	}
+	return lines
+}
+
+// concordanceKey strips the punctuation around word and, unless matchCase
+// is set, lowercases it.
+func concordanceKey(word string, matchCase bool) string {
+	word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+	if !matchCase {
+		word = strings.ToLower(word)
+	}
+	return word
+}
+
+func joinWords(words []concordanceWord) string {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.text
+	}
+	return strings.Join(texts, " ")
+}
@@ -0,0 +1,67 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestConcordance(t *testing.T) {
+	a := &yttranscript.Transcript{VideoID: "A", Texts: []yttranscript.Text{
+		{Start: 0, Content: "Never gonna give you up,"},
+		{Start: 2, Content: "never gonna let you down."},
+	}}
+	b := &yttranscript.Transcript{VideoID: "B", Texts: []yttranscript.Text{
+		{Start: 5, Content: "I will never give in"},
+	}}
+	transcripts := []*yttranscript.Transcript{a, b}
+	tests := []struct {
+		name  string
+		query string
+		opts  ConcordanceOptions
+		want  []ConcordanceLine
+	}{
+		{
+			name:  "across caption lines",
+			query: "up never",
+			opts:  ConcordanceOptions{Context: 2},
+			want:  []ConcordanceLine{{VideoID: "A", Start: 0, Left: "give you", Match: "up, never", Right: "gonna let"}},
+		},
+		{
+			name:  "wildcard",
+			query: "never * give",
+			want:  []ConcordanceLine{{VideoID: "A", Start: 0, Match: "Never gonna give", Right: "you up, never gonna let"}},
+		},
+		{
+			name:  "every transcript in order",
+			query: "NEVER",
+			opts:  ConcordanceOptions{Context: 1},
+			want: []ConcordanceLine{
+				{VideoID: "A", Start: 0, Match: "Never", Right: "gonna"},
+				{VideoID: "A", Start: 2, Left: "up,", Match: "never", Right: "gonna"},
+				{VideoID: "B", Start: 5, Left: "will", Match: "never", Right: "give"},
+			},
+		},
+		{
+			name:  "match case",
+			query: "Never",
+			opts:  ConcordanceOptions{Context: 1, MatchCase: true},
+			want:  []ConcordanceLine{{VideoID: "A", Start: 0, Match: "Never", Right: "gonna"}},
+		},
+		{
+			name:  "context stops at transcript end",
+			query: "down",
+			want:  []ConcordanceLine{{VideoID: "A", Start: 2, Left: "up, never gonna let you", Match: "down."}},
+		},
+		{
+			name:  "punctuation only query",
+			query: "...",
+		},
+	}
+	for _, tt := range tests {
+		if got := Concordance(transcripts, tt.query, tt.opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Concordance(%q) = %+v, want %+v", tt.name, tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package analyze
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+// Highlight is a candidate clip.
+type Highlight struct {
+	Start   float64 // Start time in seconds.
+	End     float64 // End time in seconds.
+	Score   float64 // Combined signal strength from 0 to 1.
+	Snippet string  // Transcript text of the range.
+	Chapter string  // Title of the chapter the range starts in, if any.
+}
+
+// HighlightOptions configures Highlights.
+type HighlightOptions struct {
+	Window  time.Duration // Length of each candidate range. Defaults to 30 seconds.
+	Limit   int           // Maximum number of highlights returned. Defaults to 5.
+	Heatmap []yttranscript.HeatMarker
+}
+
+// Signal weights. Without a heatmap the heat weight is spread over the
+// others in proportion.
+const (
+	heatWeight    = 0.5
+	keywordWeight = 0.3
+	chapterWeight = 0.2
+
+	// chapterLeadIn is how soon after a chapter starts a range must begin
+	// to get the chapter bonus.
+	chapterLeadIn = 10.0
+	// keywordsPerVideo is how many of the most frequent terms count as the
+	// video's keywords.
+	keywordsPerVideo = 20
+	// maxSnippetRunes caps the length of Highlight.Snippet.
+	maxSnippetRunes = 200
+)
+
+// Highlights ranks time ranges of the transcript as clip candidates. Each
+// range is scored on how often viewers replay it (from opts.Heatmap, see
+// Client.GetHeatmap), how densely it uses the video's most frequent terms,
+// and whether it opens a chapter. The best non-overlapping ranges are
+// returned, highest score first.
+func Highlights(transcript *yttranscript.Transcript, opts HighlightOptions) []Highlight {
+	if opts.Window <= 0 {
+		opts.Window = 30 * time.Second
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = 5
+	}
+
+	keywords := topTerms(transcript, keywordsPerVideo)
+	chunks := transcript.Rechunk(opts.Window, opts.Window/2).Texts
+
+	densities := make([]float64, len(chunks))
+	maxDensity := 0.0
+	for i, chunk := range chunks {
+		words := strings.Fields(chunk.Content)
+		if len(words) == 0 {
+			continue
+		}
+		hits := 0
+		for _, term := range terms(chunk.Content) {
+			if keywords[term] {
+				hits++
+			}
+		}
+		densities[i] = float64(hits) / float64(len(words))
+		maxDensity = max(maxDensity, densities[i])
+	}
+
+	weights := [3]float64{heatWeight, keywordWeight, chapterWeight}
+	if len(opts.Heatmap) == 0 {
+		weights = [3]float64{0, keywordWeight / (keywordWeight + chapterWeight), chapterWeight / (keywordWeight + chapterWeight)}
+	}
+
+	candidates := make([]Highlight, 0, len(chunks))
+	for i, chunk := range chunks {
+		density := 0.0
+		if maxDensity > 0 {
+			density = densities[i] / maxDensity
+		}
+		chapter, opensChapter := chapterAt(transcript.Chapters, chunk.Start)
+		bonus := 0.0
+		if opensChapter {
+			bonus = 1
+		}
+		candidates = append(candidates, Highlight{
+			Start:   chunk.Start,
+			End:     chunk.End(),
+			Score:   weights[0]*heatAt(opts.Heatmap, chunk.Start, chunk.End()) + weights[1]*density + weights[2]*bonus,
+			Snippet: truncate(chunk.Content, maxSnippetRunes),
+			Chapter: chapter,
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+
+	var highlights []Highlight
+	for _, candidate := range candidates {
+		if len(highlights) == opts.Limit {
+			break
+		}
+		overlaps := false
+		for _, h := range highlights {
+			overlaps = overlaps || candidate.Start < h.End && h.Start < candidate.End
+		}
+		if !overlaps {
+			highlights = append(highlights, candidate)
+		}
+	}
+	return highlights
+}
+
+// heatAt returns the mean intensity of the markers overlapping [from, to),
+// weighted by overlap.
+func heatAt(markers []yttranscript.HeatMarker, from, to float64) float64 {
+	total, covered := 0.0, 0.0
+	for _, marker := range markers {
+		overlap := min(to, marker.End()) - max(from, marker.Start)
+		if overlap > 0 {
+			total += marker.Intensity * overlap
+			covered += overlap
+		}
+	}
+	if covered == 0 {
+		return 0
+	}
+	return total / covered
+}
+
+// chapterAt returns the title of the chapter containing t and whether t lies
+// just after the chapter's start.
+func chapterAt(chapters []yttranscript.Chapter, t float64) (string, bool) {
+	title, opens := "", false
+	for _, chapter := range chapters {
+		if chapter.Start > t {
+			break
+		}
+		title, opens = chapter.Title, t-chapter.Start <= chapterLeadIn
+	}
+	return title, opens
+}
+
+// topTerms returns the n most frequent terms of the transcript.
+func topTerms(transcript *yttranscript.Transcript, n int) map[string]bool {
+	counts := make(map[string]int)
+	for _, text := range transcript.Texts {
+		for _, term := range terms(text.Content) {
+			counts[term]++
+		}
+	}
+	ranked := make([]string, 0, len(counts))
+	for term := range counts {
+		ranked = append(ranked, term)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if counts[ranked[i]] != counts[ranked[j]] {
+			return counts[ranked[i]] > counts[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+
+	top := make(map[string]bool, n)
+	for _, term := range ranked[:min(n, len(ranked))] {
+		top[term] = true
+	}
+	return top
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n])) + "…"
+}
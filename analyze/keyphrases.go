@@ -0,0 +1,201 @@
+package analyze
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"yt-transcript/yttranscript"
+)
+
+// KeyphraseMethod selects how Keyphrases scores candidate phrases.
+type KeyphraseMethod int
+
+const (
+	// RAKE scores a phrase by the sum of its words' degree-to-frequency
+	// ratios, favouring words that occur within longer phrases. It needs no
+	// corpus and suits a single transcript.
+	RAKE KeyphraseMethod = iota
+	// TFIDF scores a phrase by its frequency weighted by how few of the
+	// transcripts, or of the videos in KeyphraseOptions.Corpus, contain it.
+	TFIDF
+)
+
+// KeyphraseOptions configures Keyphrases.
+type KeyphraseOptions struct {
+	Method   KeyphraseMethod
+	Limit    int // Maximum number of phrases returned. Defaults to 10.
+	MaxWords int // Longest phrase considered, in words. Defaults to 3.
+	// Corpus, if set, supplies the document frequencies for TFIDF in place
+	// of the transcripts themselves. Since it counts single terms, a
+	// phrase's weight is the mean of its words' weights.
+	Corpus Corpus
+}
+
+// Keyphrase is a salient phrase of one or more transcripts.
+type Keyphrase struct {
+	Phrase      string       `json:"phrase"`
+	Count       int          `json:"count"`
+	Score       float64      `json:"score"`
+	Occurrences []Occurrence `json:"occurrences"`
+}
+
+// Occurrence locates a keyphrase in a transcript.
+type Occurrence struct {
+	VideoID string  `json:"video_id"`
+	Start   float64 `json:"start"` // Start time in seconds of the segment containing the phrase.
+}
+
+// candidate is one occurrence of a candidate phrase.
+type candidate struct {
+	words []string
+	at    Occurrence
+}
+
+// Keyphrases returns the phrases that best characterize the transcripts,
+// highest score first, with every place they occur. Candidates are the runs
+// of words between stopwords, punctuation and numbers within a segment, so
+// a phrase split across two caption lines is not found.
+func Keyphrases(transcripts []*yttranscript.Transcript, opts KeyphraseOptions) []Keyphrase {
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	if opts.MaxWords <= 0 {
+		opts.MaxWords = 3
+	}
+
+	var candidates []candidate
+	// Transcripts containing each phrase, by index, so that several
+	// transcripts of one video count separately.
+	containing := make(map[string]map[int]bool)
+	for i, transcript := range transcripts {
+		for _, text := range transcript.Texts {
+			for _, words := range phraseCandidates(text.Content, opts.MaxWords) {
+				candidates = append(candidates, candidate{words: words, at: Occurrence{VideoID: transcript.VideoID, Start: text.Start}})
+				phrase := strings.Join(words, " ")
+				if containing[phrase] == nil {
+					containing[phrase] = make(map[int]bool)
+				}
+				containing[phrase][i] = true
+			}
+		}
+	}
+
+	var score func(phrase string, words []string, count int) float64
+	switch opts.Method {
+	case TFIDF:
+		score = func(phrase string, words []string, count int) float64 {
+			idf := 0.0
+			if opts.Corpus != nil {
+				for _, word := range words {
+					idf += math.Log(float64(opts.Corpus.VideoCount()+1)/float64(opts.Corpus.VideoFrequency(word)+1)) + 1
+				}
+				idf /= float64(len(words))
+			} else {
+				idf = math.Log(float64(len(transcripts)+1)/float64(len(containing[phrase])+1)) + 1
+			}
+			return float64(count) / float64(len(candidates)) * idf
+		}
+	default:
+		wordScores := rakeWordScores(candidates)
+		score = func(_ string, words []string, _ int) float64 {
+			total := 0.0
+			for _, word := range words {
+				total += wordScores[word]
+			}
+			return total
+		}
+	}
+
+	byPhrase := make(map[string]*Keyphrase)
+	var keyphrases []*Keyphrase
+	for _, c := range candidates {
+		phrase := strings.Join(c.words, " ")
+		k := byPhrase[phrase]
+		if k == nil {
+			k = &Keyphrase{Phrase: phrase}
+			byPhrase[phrase] = k
+			keyphrases = append(keyphrases, k)
+		}
+		k.Count++
+		k.Occurrences = append(k.Occurrences, c.at)
+	}
+	for _, k := range keyphrases {
+		k.Score = score(k.Phrase, strings.Fields(k.Phrase), k.Count)
+	}
+	sort.Slice(keyphrases, func(i, j int) bool {
+		if keyphrases[i].Score != keyphrases[j].Score {
+			return keyphrases[i].Score > keyphrases[j].Score
+		}
+		return keyphrases[i].Phrase < keyphrases[j].Phrase
+	})
+
+	out := make([]Keyphrase, 0, min(opts.Limit, len(keyphrases)))
+	for _, k := range keyphrases[:min(opts.Limit, len(keyphrases))] {
+		out = append(out, *k)
+	}
+	return out
+}
+
+// rakeWordScores returns each word's degree, the total length of the
+// candidate occurrences it appears in, divided by its frequency.
+func rakeWordScores(candidates []candidate) map[string]float64 {
+	frequency := make(map[string]int)
+	degree := make(map[string]int)
+	for _, c := range candidates {
+		for _, word := range c.words {
+			frequency[word]++
+			degree[word] += len(c.words)
+		}
+	}
+	scores := make(map[string]float64, len(frequency))
+	for word, f := range frequency {
+		scores[word] = float64(degree[word]) / float64(f)
+	}
+	return scores
+}
+
+// phraseCandidates splits text into lowercase word runs, breaking at
+// stopwords, single letters and any character other than letters and
+// spaces. Runs longer than maxWords are dropped, as they are rarely
+// keyphrases and would outscore the rest under RAKE.
+func phraseCandidates(text string, maxWords int) [][]string {
+	var phrases [][]string
+	var phrase []string
+	flush := func() {
+		if len(phrase) > 0 && len(phrase) <= maxWords {
+			phrases = append(phrases, phrase)
+		}
+		phrase = nil
+	}
+
+	var word strings.Builder
+	endWord := func() {
+		if word.Len() == 0 {
+			return
+		}
+		w := word.String()
+		word.Reset()
+		if len([]rune(w)) < 2 || stopwords[w] {
+			flush()
+			return
+		}
+		phrase = append(phrase, w)
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.IsLetter(r):
+			word.WriteRune(r)
+		case unicode.IsSpace(r):
+			endWord()
+		default:
+			endWord()
+			flush()
+		}
+	}
+	endWord()
+	flush()
+	return phrases
+}
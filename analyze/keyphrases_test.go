@@ -0,0 +1,109 @@
+package analyze
+
+import (
+	"reflect"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestPhraseCandidates(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxWords int
+		want     [][]string
+	}{
+		{
+			text:     "Machine learning is a field of computer science",
+			maxWords: 3,
+			want:     [][]string{{"machine", "learning"}, {"field"}, {"computer", "science"}},
+		},
+		{
+			text:     "Deep neural network models, trained on GPUs",
+			maxWords: 3,
+			want:     [][]string{{"trained"}, {"gpus"}},
+		},
+		{
+			text:     "Deep neural network models, trained on GPUs",
+			maxWords: 4,
+			want:     [][]string{{"deep", "neural", "network", "models"}, {"trained"}, {"gpus"}},
+		},
+		{
+			text:     "version 2 release x notes",
+			maxWords: 3,
+			want:     [][]string{{"version"}, {"release"}, {"notes"}},
+		},
+		{
+			text:     "the and of",
+			maxWords: 3,
+		},
+	}
+	for _, tt := range tests {
+		if got := phraseCandidates(tt.text, tt.maxWords); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("phraseCandidates(%q, %d) = %q, want %q", tt.text, tt.maxWords, got, tt.want)
+		}
+	}
+}
+
+// fakeCorpus reports fixed document frequencies out of 100 videos.
+type fakeCorpus map[string]int
+
+func (c fakeCorpus) VideoCount() int                { return 100 }
+func (c fakeCorpus) VideoFrequency(term string) int { return c[term] }
+
+func TestKeyphrases(t *testing.T) {
+	a := &yttranscript.Transcript{VideoID: "A", Texts: []yttranscript.Text{
+		{Start: 0, Content: "machine learning"},
+		{Start: 5, Content: "machine learning models"},
+		{Start: 10, Content: "the weather"},
+	}}
+	b := &yttranscript.Transcript{VideoID: "B", Texts: []yttranscript.Text{
+		{Start: 0, Content: "the weather"},
+		{Start: 3, Content: "weather report"},
+	}}
+	tests := []struct {
+		name        string
+		transcripts []*yttranscript.Transcript
+		opts        KeyphraseOptions
+		want        []string
+	}{
+		{
+			name:        "rake favours longer phrases",
+			transcripts: []*yttranscript.Transcript{a},
+			want:        []string{"machine learning models", "machine learning", "weather"},
+		},
+		{
+			name:        "limit",
+			transcripts: []*yttranscript.Transcript{a},
+			opts:        KeyphraseOptions{Limit: 1},
+			want:        []string{"machine learning models"},
+		},
+		{
+			name:        "tfidf over the transcripts",
+			transcripts: []*yttranscript.Transcript{a, b},
+			opts:        KeyphraseOptions{Method: TFIDF},
+			want:        []string{"weather", "machine learning", "machine learning models", "weather report"},
+		},
+		{
+			name:        "tfidf over a corpus",
+			transcripts: []*yttranscript.Transcript{a, b},
+			opts:        KeyphraseOptions{Method: TFIDF, Limit: 2, Corpus: fakeCorpus{"weather": 99, "report": 50, "machine": 1, "learning": 1}},
+			want:        []string{"machine learning models", "machine learning"},
+		},
+	}
+	for _, tt := range tests {
+		var phrases []string
+		for _, k := range Keyphrases(tt.transcripts, tt.opts) {
+			phrases = append(phrases, k.Phrase)
+		}
+		if !reflect.DeepEqual(phrases, tt.want) {
+			t.Errorf("%s: phrases = %q, want %q", tt.name, phrases, tt.want)
+		}
+	}
+
+	keyphrases := Keyphrases([]*yttranscript.Transcript{a, b}, KeyphraseOptions{Method: TFIDF, Limit: 1})
+	want := []Occurrence{{VideoID: "A", Start: 10}, {VideoID: "B", Start: 0}}
+	if len(keyphrases) != 1 || keyphrases[0].Count != 2 || !reflect.DeepEqual(keyphrases[0].Occurrences, want) {
+		t.Errorf("Keyphrases = %+v, want weather twice at %+v", keyphrases, want)
+	}
+}
@@ -0,0 +1,66 @@
+package analyze
+
+import (
+	"math"
+	"sort"
+
+	"yt-transcript/yttranscript"
+)
+
+// Corpus reports how common terms are across a collection of videos, such as
+// the other videos of a channel. *index.Index implements it.
+type Corpus interface {
+	VideoCount() int
+	VideoFrequency(term string) int
+}
+
+// Keyword is a salient term of a transcript.
+type Keyword struct {
+	Term  string
+	Count int     // Occurrences in the transcript.
+	Score float64 // TF-IDF weight.
+}
+
+// Hashtag returns the keyword formatted as a hashtag.
+func (k Keyword) Hashtag() string {
+	return "#" + k.Term
+}
+
+// Keywords returns the limit terms that best characterize the transcript,
+// ranked by TF-IDF: terms frequent in this video but rare across corpus score
+// highest, so words a channel uses in every video are not suggested as tags.
+// With a nil corpus terms are ranked by frequency alone.
+func Keywords(transcript *yttranscript.Transcript, corpus Corpus, limit int) []Keyword {
+	counts := make(map[string]int)
+	total := 0
+	for _, text := range transcript.Texts {
+		for _, term := range terms(text.Content) {
+			counts[term]++
+			total++
+		}
+	}
+
+	keywords := make([]Keyword, 0, len(counts))
+	for term, count := range counts {
+		idf := 1.0
+		if corpus != nil {
+			idf = math.Log(float64(corpus.VideoCount()+1)/float64(corpus.VideoFrequency(term)+1)) + 1
+		}
+		keywords = append(keywords, Keyword{
+			Term:  term,
+			Count: count,
+			Score: float64(count) / float64(total) * idf,
+		})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Score != keywords[j].Score {
+			return keywords[i].Score > keywords[j].Score
+		}
+		return keywords[i].Term < keywords[j].Term
+	})
+
+	if limit > 0 && len(keywords) > limit {
+		keywords = keywords[:limit]
+	}
+	return keywords
+}
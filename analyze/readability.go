@@ -0,0 +1,167 @@
+package analyze
+
+import (
+	"strings"
+	"unicode"
+
+	"yt-transcript/yttranscript"
+)
+
+// Readability holds text difficulty metrics.
+type Readability struct {
+	Language         string  `json:"language"`
+	Formula          string  `json:"formula"` // Name of the reading ease formula used.
+	Sentences        int     `json:"sentences"`
+	Words            int     `json:"words"`
+	Syllables        int     `json:"syllables"`
+	WordsPerSentence float64 `json:"words_per_sentence"`
+	SyllablesPerWord float64 `json:"syllables_per_word"`
+	// ReadingEase is on the Flesch scale: higher is easier, 60-70 is plain
+	// language.
+	ReadingEase float64 `json:"reading_ease"`
+	// Grade is the school grade needed to follow the text, where a formula
+	// for the language exists (Flesch-Kincaid for English, the fourth Wiener
+	// Sachtextformel for German), and 0 otherwise.
+	Grade float64 `json:"grade,omitempty"`
+	// SentencesEstimated is set when the captions carry no sentence
+	// punctuation, as auto-generated ones usually do, and caption lines
+	// were counted as sentences instead.
+	SentencesEstimated bool `json:"sentences_estimated,omitempty"`
+}
+
+// ChapterReadability is the readability of one chapter.
+type ChapterReadability struct {
+	Chapter     yttranscript.Chapter `json:"chapter"`
+	Readability Readability          `json:"readability"`
+}
+
+// readabilityFormula adapts Flesch's reading ease to a language.
+type readabilityFormula struct {
+	name   string
+	vowels string
+	// ease computes reading ease from words per sentence and syllables
+	// per word.
+	ease func(wps, spw float64) float64
+}
+
+var readabilityFormulas = map[string]readabilityFormula{
+	"en": {"Flesch", "aeiouy", func(wps, spw float64) float64 { return 206.835 - 1.015*wps - 84.6*spw }},
+	"de": {"Amstad", "aeiouyäöü", func(wps, spw float64) float64 { return 180 - wps - 58.5*spw }},
+	"es": {"Fernández Huerta", "aeiouáéíóúü", func(wps, spw float64) float64 { return 206.84 - 1.02*wps - 60*spw }},
+	"fr": {"Kandel-Moles", "aeiouyàâéèêëîïôûùüÿœæ", func(wps, spw float64) float64 { return 207 - 1.015*wps - 73.6*spw }},
+	"it": {"Franchina-Vacca", "aeiouàèéìíòóùú", func(wps, spw float64) float64 { return 217 - 1.3*wps - 60*spw }},
+	"nl": {"Douma", "aeiouyë", func(wps, spw float64) float64 { return 206.835 - 0.93*wps - 77*spw }},
+	"pt": {"Martins", "aeiouáâãàéêíóôõú", func(wps, spw float64) float64 { return 248.835 - 1.015*wps - 84.6*spw }},
+}
+
+// minWordsForPunctuation is the length from which a transcript without a
+// single sentence break is assumed to lack punctuation altogether.
+const minWordsForPunctuation = 40
+
+// ReadabilityOf scores the transcript with the reading ease formula for its
+// language, falling back to English's Flesch formula for languages without
+// one.
+func ReadabilityOf(transcript *yttranscript.Transcript) Readability {
+	return readability(transcript, lacksPunctuation(transcript))
+}
+
+// lacksPunctuation reports whether a transcript of some length has no
+// sentence breaks at all, so caption lines must stand in for sentences.
+func lacksPunctuation(transcript *yttranscript.Transcript) bool {
+	words := 0
+	for _, text := range transcript.Texts {
+		words += len(strings.Fields(text.Content))
+	}
+	return words >= minWordsForPunctuation && len(transcript.Sentences(yttranscript.SentenceOptions{}).Texts) <= 1
+}
+
+func readability(transcript *yttranscript.Transcript, estimateSentences bool) Readability {
+	language := strings.ToLower(strings.SplitN(transcript.LanguageCode, "-", 2)[0])
+	formula, ok := readabilityFormulas[language]
+	if !ok {
+		formula = readabilityFormulas["en"]
+	}
+
+	r := Readability{Language: language, Formula: formula.name}
+	polysyllables := 0
+	for _, text := range transcript.Texts {
+		for _, word := range strings.FieldsFunc(strings.ToLower(text.Content), func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' }) {
+			syllables := countSyllables(word, formula.vowels, language)
+			r.Words++
+			r.Syllables += syllables
+			if syllables >= 3 {
+				polysyllables++
+			}
+		}
+	}
+	if r.Words == 0 {
+		return r
+	}
+
+	if estimateSentences {
+		r.Sentences = len(transcript.Texts)
+		r.SentencesEstimated = true
+	} else {
+		r.Sentences = len(transcript.Sentences(yttranscript.SentenceOptions{}).Texts)
+	}
+	r.WordsPerSentence = float64(r.Words) / float64(max(r.Sentences, 1))
+	r.SyllablesPerWord = float64(r.Syllables) / float64(r.Words)
+	r.ReadingEase = formula.ease(r.WordsPerSentence, r.SyllablesPerWord)
+
+	switch language {
+	case "en":
+		r.Grade = 0.39*r.WordsPerSentence + 11.8*r.SyllablesPerWord - 15.59
+	case "de":
+		r.Grade = 0.2656*r.WordsPerSentence + 0.2744*100*float64(polysyllables)/float64(r.Words) - 1.693
+	}
+	return r
+}
+
+// ReadabilityByChapter scores each chapter of the transcript separately. It
+// returns nil for transcripts without chapters.
+func ReadabilityByChapter(transcript *yttranscript.Transcript) []ChapterReadability {
+	estimateSentences := lacksPunctuation(transcript)
+	var out []ChapterReadability
+	for i, chapter := range transcript.Chapters {
+		end := -1.0
+		if i+1 < len(transcript.Chapters) {
+			end = transcript.Chapters[i+1].Start
+		}
+		var texts []yttranscript.Text
+		for _, text := range transcript.Texts {
+			if text.Start >= chapter.Start && (end < 0 || text.Start < end) {
+				texts = append(texts, text)
+			}
+		}
+		section := *transcript
+		section.Texts = texts
+		out = append(out, ChapterReadability{Chapter: chapter, Readability: readability(&section, estimateSentences)})
+	}
+	return out
+}
+
+// countSyllables estimates the syllables of a lowercase word as its number
+// of vowel groups, discounting the silent final e of English and French.
+func countSyllables(word, vowels, language string) int {
+	count := 0
+	inVowels := false
+	for _, r := range word {
+		isVowel := strings.ContainsRune(vowels, r)
+		if isVowel && !inVowels {
+			count++
+		}
+		inVowels = isVowel
+	}
+
+	switch language {
+	case "en":
+		if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+			count--
+		}
+	case "fr":
+		if (strings.HasSuffix(word, "e") || strings.HasSuffix(word, "es")) && count > 1 {
+			count--
+		}
+	}
+	return max(count, 1)
+}
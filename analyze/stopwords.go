@@ -0,0 +1,28 @@
+package analyze
+
+// stopwords are common English words that carry no topic on their own, plus
+// filler frequent in speech.
+var stopwords = toSet(
+	"a", "about", "above", "after", "again", "against", "all", "also", "am", "an", "and", "any", "are", "as", "at",
+	"be", "because", "been", "before", "being", "below", "between", "both", "but", "by",
+	"can", "could", "did", "do", "does", "doing", "don", "down", "during",
+	"each", "even", "every", "few", "for", "from", "further", "get", "got", "had", "has", "have", "having",
+	"he", "her", "here", "hers", "herself", "him", "himself", "his", "how",
+	"i", "if", "in", "into", "is", "it", "its", "itself", "just", "know", "let", "like", "ll",
+	"me", "might", "more", "most", "much", "must", "my", "myself", "no", "nor", "not", "now",
+	"of", "off", "oh", "ok", "okay", "on", "once", "one", "only", "or", "other", "our", "ours", "ourselves", "out", "over", "own",
+	"re", "really", "right", "s", "said", "same", "say", "see", "she", "should", "so", "some", "such",
+	"t", "than", "that", "the", "their", "theirs", "them", "themselves", "then", "there", "these", "they", "thing", "things",
+	"think", "this", "those", "through", "to", "too", "um", "uh", "under", "until", "up", "us",
+	"ve", "very", "want", "was", "way", "we", "well", "were", "what", "when", "where", "which", "while", "who", "whom", "why",
+	"will", "with", "would", "yeah", "yes", "you", "your", "yours", "yourself", "yourselves",
+	"going", "gonna", "gotta", "wanna",
+)
+
+func toSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
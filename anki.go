@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+// runAnki writes dual-language flashcards from two caption tracks of a
+// video, pairing the lines of the second track with those of the first by
+// time.
+func runAnki(args []string) {
+	fs := flag.NewFlagSet("anki", flag.ExitOnError)
+	translate := fs.Bool("translate", false, "use YouTube's machine translation for the back instead of a caption track")
+	fs.Parse(args)
+
+	if fs.NArg() < 3 {
+		log.Fatal(usage)
+	}
+	videoID, frontLanguage, backLanguage := videoIDArg(fs.Arg(0)), fs.Arg(1), fs.Arg(2)
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	var pair *yttranscript.TranscriptPair
+	if *translate {
+		if pair, err = client.GetTranscriptPair(videoID, frontLanguage, backLanguage); err != nil {
+			log.Fatalf("Failed to get transcript pair: %v", err)
+		}
+	} else {
+		transcripts, err := client.GetTranscripts(videoID, []string{frontLanguage, backLanguage})
+		if err != nil {
+			log.Fatalf("Failed to get transcripts: %v", err)
+		}
+		pair = yttranscript.AlignTranscripts(transcripts[0], transcripts[1])
+	}
+
+	if err := export.WriteAnki(os.Stdout, pair); err != nil {
+		log.Fatalf("Failed to write flashcards: %v", err)
+	}
+}
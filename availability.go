@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"yt-transcript/yttranscript"
+)
+
+// availability is one row of the language availability matrix.
+type availability struct {
+	VideoID       string              `json:"video_id"`
+	Title         string              `json:"title,omitempty"`
+	LengthSeconds int                 `json:"length_seconds,omitempty"`
+	Languages     map[string][]string `json:"languages"` // Track kinds by language code.
+	Error         string              `json:"error,omitempty"`
+}
+
+// runAvailability prints which caption languages and kinds exist for each of
+// a list of videos, without downloading any transcript.
+func runAvailability(args []string) {
+	fs := flag.NewFlagSet("availability", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the matrix as JSON instead of CSV")
+	idsPath := fs.String("ids", "", "file with one video ID per line, read in addition to the arguments")
+	concurrency := fs.Int("concurrency", 4, "number of videos looked up at once")
+	nice := registerNiceFlag(fs)
+	fs.Parse(args)
+	interrupt = watchInterrupts()
+
+	var videoIDs []string
+	for _, arg := range fs.Args() {
+		videoIDs = append(videoIDs, videoIDArg(arg))
+	}
+	if *idsPath != "" {
+		ids, err := readVideoIDs(*idsPath)
+		if err != nil {
+			log.Fatalf("Failed to read video IDs: %v", err)
+		}
+		for _, id := range ids {
+			videoIDs = append(videoIDs, videoIDArg(id))
+		}
+	}
+	if len(videoIDs) == 0 || *concurrency < 1 {
+		log.Fatal(usage)
+	}
+
+	pacer := newPacer(*nice)
+	if *nice {
+		*concurrency = 1
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	rows := make([]availability, len(videoIDs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range *concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// A video already scheduled is looked up even if a stop
+				// signal cuts the pause short, so that its row is filled.
+				pacer.wait()
+				rows[i] = lookupAvailability(client, videoIDs[i])
+				pacer.done()
+			}
+		}()
+	}
+	// After a stop signal no more videos are started, and the matrix is
+	// written for those looked up so far.
+	looked := len(videoIDs)
+schedule:
+	for i := range videoIDs {
+		select {
+		case jobs <- i:
+		case <-interrupt.Done():
+			looked = i
+			break schedule
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	rows = rows[:looked]
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rows); err != nil {
+			log.Fatalf("Failed to write matrix: %v", err)
+		}
+		exitIfInterrupted()
+		return
+	}
+	if err := writeAvailabilityCSV(rows); err != nil {
+		log.Fatalf("Failed to write matrix: %v", err)
+	}
+	exitIfInterrupted()
+}
+
+func lookupAvailability(client *yttranscript.Client, videoID string) availability {
+	row := availability{VideoID: videoID, Languages: make(map[string][]string)}
+	metadata, err := client.GetMetadata(videoID)
+	if err != nil {
+		row.Error = err.Error()
+		return row
+	}
+	row.Title = metadata.Title
+	row.LengthSeconds = metadata.LengthSeconds
+	for _, track := range metadata.Captions.Tracks {
+		if kinds := row.Languages[track.LanguageCode]; !slices.Contains(kinds, track.Kind) {
+			row.Languages[track.LanguageCode] = append(kinds, track.Kind)
+		}
+	}
+	return row
+}
+
+// writeAvailabilityCSV writes one row per video and one column per language
+// seen in any video. Cells hold the kinds of track available, such as
+// "manual", "asr" or "manual+asr", and are empty when there is none.
+func writeAvailabilityCSV(rows []availability) error {
+	var languages []string
+	for _, row := range rows {
+		for language := range row.Languages {
+			if !slices.Contains(languages, language) {
+				languages = append(languages, language)
+			}
+		}
+	}
+	slices.Sort(languages)
+
+	w := csv.NewWriter(os.Stdout)
+	w.Write(append(append([]string{"video_id", "title", "length_seconds"}, languages...), "error"))
+	for _, row := range rows {
+		record := []string{row.VideoID, row.Title, strconv.Itoa(row.LengthSeconds)}
+		for _, language := range languages {
+			kinds := slices.Clone(row.Languages[language])
+			slices.Sort(kinds)
+			slices.Reverse(kinds) // "manual" before "asr".
+			record = append(record, strings.Join(kinds, "+"))
+		}
+		w.Write(append(record, row.Error))
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// readVideoIDs reads one video ID per line, skipping blank lines and lines
+// starting with #.
+func readVideoIDs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			ids = append(ids, line)
+		}
+	}
+	return ids, scanner.Err()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"yt-transcript/yttranscript"
+)
+
+// runCompare reports where a video's transcript deviates from an intended
+// script.
+func runCompare(args []string) {
+	if len(args) < 2 {
+		log.Fatal(usage)
+	}
+	videoID, scriptPath := videoIDArg(args[0]), args[1]
+	languageCode := ""
+	if len(args) > 2 {
+		languageCode = args[2]
+	}
+
+	script, err := os.ReadFile(scriptPath)
+	if err != nil {
+		log.Fatalf("Failed to read script: %v", err)
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	changes := transcript.CompareScript(string(script))
+	if len(changes) == 0 {
+		fmt.Println("The transcript matches the script.")
+		return
+	}
+	for _, change := range changes {
+		fmt.Printf("[%s] %s\n", yttranscript.FormatTimestamp(change.Start), change.Kind)
+		if change.Before != "" {
+			fmt.Printf("  script: %s\n", change.Before)
+		}
+		if change.After != "" {
+			fmt.Printf("  said:   %s\n", change.After)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"yt-transcript/analyze"
+	"yt-transcript/index"
+	"yt-transcript/yttranscript"
+)
+
+// runConcordance prints a keyword-in-context listing of a word or phrase
+// across the transcripts of the given videos or of an index file.
+func runConcordance(args []string) {
+	fs := flag.NewFlagSet("concordance", flag.ExitOnError)
+	indexPath := fs.String("index", "", "index file whose transcripts to search instead of fetching videos")
+	context := fs.Int("context", 5, "words of context on each side")
+	matchCase := fs.Bool("case", false, "match the case of the query")
+	languageCode := fs.String("lang", "", "language code of the transcripts to fetch")
+	asJSON := fs.Bool("json", false, "print lines as JSON")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 || *indexPath == "" && fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+	query := fs.Arg(0)
+
+	var transcripts []*yttranscript.Transcript
+	if *indexPath != "" {
+		ix, err := index.Open(*indexPath)
+		if err != nil {
+			log.Fatalf("Failed to open index: %v", err)
+		}
+		transcripts = ix.Transcripts()
+	}
+	if fs.NArg() > 1 {
+		client, err := yttranscript.New()
+		if err != nil {
+			log.Fatalf("Failed to create client: %v", err)
+		}
+		for _, arg := range fs.Args()[1:] {
+			videoID := videoIDArg(arg)
+			transcript, err := client.GetTranscript(videoID, *languageCode)
+			if err != nil {
+				log.Fatalf("Failed to get transcript of %s: %v", videoID, err)
+			}
+			transcripts = append(transcripts, transcript)
+		}
+	}
+
+	lines := analyze.Concordance(transcripts, query, analyze.ConcordanceOptions{Context: *context, MatchCase: *matchCase})
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(lines); err != nil {
+			log.Fatalf("Failed to write concordance: %v", err)
+		}
+		return
+	}
+
+	// Right-aligning the left context lines the matches up in one column.
+	labels := make([]string, len(lines))
+	labelWidth, leftWidth := 0, 0
+	for i, line := range lines {
+		labels[i] = line.VideoID + "@" + yttranscript.FormatTimestamp(line.Start)
+		labelWidth = max(labelWidth, utf8.RuneCountInString(labels[i]))
+		leftWidth = max(leftWidth, utf8.RuneCountInString(line.Left))
+	}
+	for i, line := range lines {
+		fmt.Println(strings.TrimRight(fmt.Sprintf("%-*s  %*s  %s  %s", labelWidth, labels[i], leftWidth, line.Left, line.Match, line.Right), " "))
+	}
+}
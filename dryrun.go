@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+// Typical caption tracks, used to turn video durations into transcript
+// volume for -dry-run.
+const (
+	estimateSegmentSeconds = 3.5 // Average length of a caption segment.
+	estimateCharsPerSecond = 14  // Caption text per second of speech, about 150 words a minute.
+)
+
+// requestsPerVideo are the requests a fetch needs when the first client
+// profile has the captions: the player request and the caption track.
+const requestsPerVideo = 2
+
+// estimate is the expected cost of fetching a batch of videos.
+type estimate struct {
+	Videos          int
+	UnknownDuration int           // Videos whose duration could not be looked up.
+	Duration        time.Duration // Total length of the videos with a known duration.
+	Bytes           int64         // Transcript volume in the output format.
+	Requests        int
+	Elapsed         time.Duration
+	PerRequest      time.Duration // Measured latency of a player request.
+}
+
+// printEstimate looks up the duration of every upload a poll would fetch,
+// without downloading transcripts or touching the state file, and prints the
+// expected volume, requests and time. A positive interval adds the feed
+// requests of a watch.
+func (j *feedJob) printEstimate(interval time.Duration) {
+	fresh, err := j.freshEntries()
+	if err != nil {
+		log.Fatalf("Failed to get channel feed: %v", err)
+	}
+	write, _ := export.Writer(j.format, export.Options{})
+
+	// The feed and the watch page holding the InnerTube configuration are
+	// fetched once per run.
+	est := estimate{Videos: len(fresh), Requests: 2 + requestsPerVideo*len(fresh)}
+	var lookups time.Duration
+	for _, entry := range fresh {
+		if interrupt.Err() != nil {
+			break
+		}
+		started := time.Now()
+		metadata, err := j.client.GetMetadata(entry.VideoID)
+		lookups += time.Since(started)
+		if err != nil {
+			log.Printf("Warning: %s (%s): %v; duration unknown", entry.VideoID, entry.Title, err)
+			est.UnknownDuration++
+			continue
+		}
+		est.Duration += metadata.Duration()
+		est.Bytes += estimateBytes(write, metadata.Duration())
+	}
+	if len(fresh) > 0 {
+		// Each fetch makes a player request like the lookup and downloads
+		// the track, which takes about as long.
+		est.PerRequest = lookups / time.Duration(len(fresh))
+		est.Elapsed = time.Duration(requestsPerVideo*len(fresh)) * est.PerRequest
+		if j.nice {
+			est.Elapsed *= 2
+		}
+	}
+	est.print(os.Stdout, j.format, j.nice, interval)
+	exitIfInterrupted()
+}
+
+func (e estimate) print(out io.Writer, format string, nice bool, interval time.Duration) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	videos := fmt.Sprintf("%d new", e.Videos)
+	if e.UnknownDuration > 0 {
+		videos += fmt.Sprintf(" (%d without a known duration)", e.UnknownDuration)
+	}
+	fmt.Fprintf(w, "Videos:\t%s\n", videos)
+	fmt.Fprintf(w, "Video duration:\t%s\n", e.Duration)
+	fmt.Fprintf(w, "Transcript volume:\t~%s as %s\n", formatSize(e.Bytes), format)
+	fmt.Fprintf(w, "Requests:\t~%d (feed, watch page and %d per video; more when client fallbacks are needed)\n", e.Requests, requestsPerVideo)
+	elapsed := fmt.Sprintf("~%s at %s per request", e.Elapsed.Round(time.Second), e.PerRequest.Round(time.Millisecond))
+	if nice {
+		elapsed += ", with nice mode pausing as long as each video took"
+	}
+	fmt.Fprintf(w, "Time:\t%s\n", elapsed)
+	if interval > 0 {
+		perDay := int(math.Ceil(float64(24*time.Hour) / float64(interval)))
+		fmt.Fprintf(w, "Polling:\t%d feed requests a day at one per %s, plus the fetches of new uploads\n", perDay, interval)
+	}
+	w.Flush()
+}
+
+// estimateBytes renders a stand-in transcript of the given length in the
+// output format and returns its size, so that the estimate includes the
+// format's per-segment overhead.
+func estimateBytes(write export.WriterFunc, length time.Duration) int64 {
+	segments := int(math.Ceil(length.Seconds() / estimateSegmentSeconds))
+	content := strings.Repeat("word ", int(estimateSegmentSeconds*estimateCharsPerSecond)/5)
+	transcript := &yttranscript.Transcript{VideoID: "dryrun00000", LanguageCode: "en", Texts: make([]yttranscript.Text, segments)}
+	for i := range transcript.Texts {
+		transcript.Texts[i] = yttranscript.Text{Start: float64(i) * estimateSegmentSeconds, Duration: estimateSegmentSeconds, Content: strings.TrimSpace(content)}
+	}
+	var counter byteCounter
+	if err := write(&counter, transcript); err != nil {
+		return 0
+	}
+	return int64(counter)
+}
+
+// byteCounter is an io.Writer counting what is written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// formatSize formats a byte count with a binary unit.
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	size, exp := float64(bytes), 0
+	for size >= unit && exp < 4 {
+		size /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", size, "KMGT"[exp-1])
+}
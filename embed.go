@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"yt-transcript/pipeline"
+	"yt-transcript/yttranscript"
+)
+
+// minWindow is the shortest -window accepted, as shorter ones only split the
+// transcript into its caption lines at great cost.
+const minWindow = time.Second
+
+// runEmbed writes transcript chunks with their embeddings as JSONL, using an
+// OpenAI-compatible endpoint configured through the OPENAI_* environment
+// variables.
+func runEmbed(args []string) {
+	fs := flag.NewFlagSet("embed", flag.ExitOnError)
+	window := fs.Duration("window", time.Minute, "time covered by each chunk")
+	overlap := fs.Duration("overlap", 10*time.Second, "overlap between consecutive chunks")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+	if *window < minWindow {
+		log.Fatalf("-window must be at least %v", minWindow)
+	}
+	videoID := videoIDArg(fs.Arg(0))
+	languageCode := fs.Arg(1)
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	opts := pipeline.EmbedOptions{Window: *window, Overlap: *overlap}
+	if err := pipeline.WriteEmbeddingsJSONL(context.Background(), os.Stdout, transcript, pipeline.OpenAIEmbedderFromEnv(), opts); err != nil {
+		log.Fatalf("Failed to export embeddings: %v", err)
+	}
+}
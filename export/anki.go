@@ -0,0 +1,51 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// ankiFieldReplacer flattens caption text to a single card field.
+var ankiFieldReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+
+// WriteAnki writes a transcript pair to w as Anki flashcards, one per line
+// with both sides present: the original on the front, the other language on
+// the back, and a link to the moment in the video as a third field. The file
+// is tab-separated with a header that tells Anki (2.1.55 and later) the
+// separator, columns and tags, so File > Import needs no further settings.
+// Pairs can come from GetTranscriptPair or AlignTranscripts.
+func WriteAnki(w io.Writer, pair *yttranscript.TranscriptPair) error {
+	original, translated := pair.Original, pair.Translated
+	header := fmt.Sprintf("#separator:tab\n#html:false\n#columns:Front\tBack\tLink\n#tags:%s %s_%s\n",
+		ankiTag(original.VideoID), ankiTag(original.LanguageCode), ankiTag(translated.LanguageCode))
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = '\t'
+	for i, text := range original.Texts {
+		front := strings.TrimSpace(ankiFieldReplacer.Replace(text.Content))
+		back := ""
+		if i < len(translated.Texts) {
+			back = strings.TrimSpace(ankiFieldReplacer.Replace(translated.Texts[i].Content))
+		}
+		if front == "" || back == "" {
+			continue
+		}
+		if err := writer.Write([]string{front, back, yttranscript.DeepLink(original.VideoID, text.Start)}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ankiTag makes s usable as an Anki tag, which cannot contain spaces.
+func ankiTag(s string) string {
+	return strings.ReplaceAll(s, " ", "_")
+}
@@ -0,0 +1,33 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestWriteAnki(t *testing.T) {
+	original := &yttranscript.Transcript{VideoID: "dQw4w9WgXcQ", LanguageCode: "en", Texts: []yttranscript.Text{
+		{Start: 18.64, Content: "We're no strangers\nto love"},
+		{Start: 22.64, Content: "Untranslated"},
+		{Start: 27.04, Content: `Say "hi"`},
+		{Start: 31.2, Content: "Past the end"},
+	}}
+	translated := &yttranscript.Transcript{LanguageCode: "pt BR", Texts: []yttranscript.Text{
+		{Start: 18.64, Content: "Não somos estranhos\tao amor"},
+		{Start: 22.64, Content: " "},
+		{Start: 27.04, Content: `Diga "oi"`},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteAnki(&buf, &yttranscript.TranscriptPair{Original: original, Translated: translated}); err != nil {
+		t.Fatal(err)
+	}
+	want := "#separator:tab\n#html:false\n#columns:Front\tBack\tLink\n#tags:dQw4w9WgXcQ en_pt_BR\n" +
+		"We're no strangers to love\tNão somos estranhos ao amor\thttps://youtu.be/dQw4w9WgXcQ?t=18\n" +
+		"\"Say \"\"hi\"\"\"\t\"Diga \"\"oi\"\"\"\thttps://youtu.be/dQw4w9WgXcQ?t=27\n"
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
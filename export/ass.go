@@ -0,0 +1,70 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// assHeader declares a 720p script with a single bottom-centred style.
+const assHeader = `[Script Info]
+Title: %s
+ScriptType: v4.00+
+PlayResX: 1280
+PlayResY: 720
+WrapStyle: 0
+ScaledBorderAndShadow: yes
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Default,Arial,48,&H00FFFFFF,&H000000FF,&H00000000,&H80000000,0,0,0,0,100,100,0,0,1,2,1,2,40,40,40,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`
+
+var assTextReplacer = strings.NewReplacer(
+	"\r\n", `\N`,
+	"\n", `\N`,
+	"{", `\{`,
+	"}", `\}`,
+)
+
+// WriteASS writes the transcript to w as an Advanced SubStation Alpha script
+// with a basic default style, ready to be burned in with ffmpeg's subtitles
+// filter.
+func WriteASS(w io.Writer, transcript *yttranscript.Transcript) error {
+	return writeASS(w, transcript, Options{})
+}
+
+func writeASS(w io.Writer, transcript *yttranscript.Transcript, opts Options) error {
+	title := transcript.Title
+	if title == "" {
+		title = transcript.VideoID
+	}
+	header := fmt.Sprintf(assHeader, title)
+	if note := machineNote(transcript); opts.Annotate && note != "" {
+		// Lines starting with a semicolon are comments in the script info.
+		header = strings.Replace(header, "\n", "\n; "+note+"\n", 1)
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	for _, text := range transcript.Texts {
+		_, err := fmt.Fprintf(w, "Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+			assTimestamp(text.Start), assTimestamp(text.End()), assTextReplacer.Replace(text.Content))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assTimestamp renders seconds as h:mm:ss.cc.
+func assTimestamp(seconds float64) string {
+	centis := int(seconds*100 + 0.5)
+	return fmt.Sprintf("%d:%02d:%02d.%02d", centis/360000, centis/6000%60, centis/100%60, centis%100)
+}
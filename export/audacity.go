@@ -0,0 +1,28 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// audacityLabelReplacer flattens caption text to a single label line.
+var audacityLabelReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ")
+
+// WriteAudacityLabels writes the transcript to w as an Audacity label track,
+// one "start<TAB>end<TAB>text" line per segment with times in seconds, to be
+// loaded next to the audio with File > Import > Labels. Empty segments are
+// skipped.
+func WriteAudacityLabels(w io.Writer, transcript *yttranscript.Transcript) error {
+	var b strings.Builder
+	for _, text := range transcript.Texts {
+		if strings.TrimSpace(text.Content) == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%.6f\t%.6f\t%s\n", text.Start, text.End(), audacityLabelReplacer.Replace(text.Content))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestWriteAudacityLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []yttranscript.Text
+		want  string
+	}{
+		{
+			name:  "segments",
+			texts: []yttranscript.Text{{Start: 0, Duration: 1.5, Content: "hello"}, {Start: 18.64, Duration: 3.24, Content: "world"}},
+			want:  "0.000000\t1.500000\thello\n18.640000\t21.880000\tworld\n",
+		},
+		{
+			name:  "line breaks and tabs flattened",
+			texts: []yttranscript.Text{{Start: 1, Duration: 1, Content: "one\ntwo\r\nthree\tfour"}},
+			want:  "1.000000\t2.000000\tone two three four\n",
+		},
+		{
+			name:  "empty segments skipped",
+			texts: []yttranscript.Text{{Start: 1, Duration: 1, Content: " "}, {Start: 2, Duration: 1, Content: "kept"}},
+			want:  "2.000000\t3.000000\tkept\n",
+		},
+		{
+			name: "no segments",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteAudacityLabels(&buf, &yttranscript.Transcript{Texts: tt.texts}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, buf.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"yt-transcript/yttranscript"
+)
+
+// Options controls optional output of the export formats.
+type Options struct {
+	// ComputedFields adds each segment's index, end time, and character and
+	// word counts to csv, tsv and json output, so that consumers do not have
+	// to derive them.
+	ComputedFields bool
+
+	// Annotate marks automatic captions and machine translations as such in
+	// json, markdown and ass output, so that they are not mistaken for
+	// captions written by a person.
+	Annotate bool
+
+	// Sections adds a heading at every topic change found by
+	// Transcript.Segments to markdown output of videos without chapters,
+	// naming the section's most characteristic terms.
+	Sections bool
+}
+
+// computedHeader names the columns added by Options.ComputedFields.
+var computedHeader = []string{"index", "end", "chars", "words"}
+
+// computedRecord returns the computed columns for the i-th segment.
+func computedRecord(i int, text yttranscript.Text) []string {
+	return []string{
+		strconv.Itoa(i),
+		formatSeconds(roundTime(text.End())),
+		strconv.Itoa(utf8.RuneCountInString(text.Content)),
+		strconv.Itoa(len(strings.Fields(text.Content))),
+	}
+}
+
+// machineNote describes how a machine produced the transcript's text, or
+// returns "" for captions written by a person.
+func machineNote(transcript *yttranscript.Transcript) string {
+	p := transcript.Provenance
+	switch {
+	case p.TranslatedFrom != "" && p.Kind == "asr":
+		return fmt.Sprintf("Machine translation from '%s' of automatically generated captions; neither was reviewed by a person.", p.TranslatedFrom)
+	case p.TranslatedFrom != "":
+		return fmt.Sprintf("Machine translation from '%s'; not reviewed by a person.", p.TranslatedFrom)
+	case p.Kind == "asr":
+		return "Automatically generated captions (speech recognition); not reviewed by a person."
+	}
+	return ""
+}
+
+// Writer returns the writer for the named format with opts applied. Formats
+// without optional output ignore opts.
+func Writer(name string, opts Options) (WriterFunc, bool) {
+	switch name {
+	case "csv":
+		return func(w io.Writer, transcript *yttranscript.Transcript) error {
+			return writeDelimited(w, transcript, ',', opts)
+		}, true
+	case "tsv":
+		return func(w io.Writer, transcript *yttranscript.Transcript) error {
+			return writeDelimited(w, transcript, '\t', opts)
+		}, true
+	case "json":
+		return func(w io.Writer, transcript *yttranscript.Transcript) error {
+			return writeJSON(w, transcript, opts)
+		}, true
+	case "markdown":
+		return func(w io.Writer, transcript *yttranscript.Transcript) error {
+			return writeMarkdown(w, transcript, opts)
+		}, true
+	case "ass":
+		return func(w io.Writer, transcript *yttranscript.Transcript) error {
+			return writeASS(w, transcript, opts)
+		}, true
+	}
+	write, ok := Formats[name]
+	return write, ok
+}
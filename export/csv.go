@@ -0,0 +1,60 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"yt-transcript/yttranscript"
+)
+
+// csvHeader is the header row written by WriteCSV and WriteTSV. Computed
+// columns, when requested, follow it.
+var csvHeader = []string{"video_id", "language", "start", "duration", "text"}
+
+// WriteCSV writes the transcript to w as comma-separated values with one row
+// per line of text.
+func WriteCSV(w io.Writer, transcript *yttranscript.Transcript) error {
+	return writeDelimited(w, transcript, ',', Options{})
+}
+
+// WriteTSV writes the transcript to w as tab-separated values with one row
+// per line of text.
+func WriteTSV(w io.Writer, transcript *yttranscript.Transcript) error {
+	return writeDelimited(w, transcript, '\t', Options{})
+}
+
+func writeDelimited(w io.Writer, transcript *yttranscript.Transcript, comma rune, opts Options) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+
+	header := csvHeader
+	if opts.ComputedFields {
+		header = append(header[:len(header):len(header)], computedHeader...)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for i, text := range transcript.Texts {
+		record := []string{
+			transcript.VideoID,
+			transcript.LanguageCode,
+			formatSeconds(text.Start),
+			formatSeconds(text.Duration),
+			text.Content,
+		}
+		if opts.ComputedFields {
+			record = append(record, computedRecord(i, text)...)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', -1, 64)
+}
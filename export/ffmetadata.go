@@ -0,0 +1,66 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// ffmetadataEscaper escapes the characters FFMETADATA files give a meaning.
+var ffmetadataEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"=", `\=`,
+	";", `\;`,
+	"#", `\#`,
+	"\n", "\\\n",
+)
+
+// WriteFFMetadata writes the transcript's chapters to w as an ffmpeg
+// FFMETADATA file, to add them to a downloaded copy of the video:
+//
+//	ffmpeg -i video.mp4 -i chapters.txt -map_metadata 1 -map_chapters 1 -codec copy out.mp4
+//
+// Videos without chapters get one per topical section found by
+// Transcript.Segments. Each chapter ends where the next begins, and the
+// last where the captions end.
+func WriteFFMetadata(w io.Writer, transcript *yttranscript.Transcript) error {
+	chapters := transcript.Chapters
+	if len(chapters) == 0 {
+		for i, section := range transcript.Segments(yttranscript.SegmentOptions{}) {
+			chapters = append(chapters, yttranscript.Chapter{Title: sectionTitle(i, section), Start: section.Start})
+		}
+		// Players expect the first chapter to start with the video.
+		if len(chapters) > 0 {
+			chapters[0].Start = 0
+		}
+	}
+	end := 0.0
+	for _, text := range transcript.Texts {
+		end = max(end, text.End())
+	}
+
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	if transcript.Title != "" {
+		fmt.Fprintf(&b, "title=%s\n", ffmetadataEscaper.Replace(transcript.Title))
+	}
+	for i, chapter := range chapters {
+		chapterEnd := end
+		if i+1 < len(chapters) {
+			chapterEnd = chapters[i+1].Start
+		}
+		start := milliseconds(chapter.Start)
+		fmt.Fprintf(&b, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			start, max(milliseconds(chapterEnd), start+1), ffmetadataEscaper.Replace(chapter.Title))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func milliseconds(seconds float64) int64 {
+	return int64(math.Round(seconds * 1000))
+}
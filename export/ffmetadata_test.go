@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestWriteFFMetadata(t *testing.T) {
+	tests := []struct {
+		name       string
+		transcript *yttranscript.Transcript
+		want       string
+	}{
+		{
+			name: "chapters",
+			transcript: &yttranscript.Transcript{
+				Title:    "Q&A; part #1 = fun",
+				Texts:    []yttranscript.Text{{Start: 0.5, Duration: 2, Content: "hi"}, {Start: 90, Duration: 10.0004, Content: "bye"}},
+				Chapters: []yttranscript.Chapter{{Start: 0, Title: "Intro"}, {Start: 61.25, Title: "Back\\slash"}},
+			},
+			want: ";FFMETADATA1\ntitle=Q&A\\; part \\#1 \\= fun\n" +
+				"\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=0\nEND=61250\ntitle=Intro\n" +
+				"\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=61250\nEND=100000\ntitle=Back\\\\slash\n",
+		},
+		{
+			name: "chapter after the captions",
+			transcript: &yttranscript.Transcript{
+				Texts:    []yttranscript.Text{{Start: 0, Duration: 5, Content: "hi"}},
+				Chapters: []yttranscript.Chapter{{Start: 0, Title: "One"}, {Start: 10, Title: "Two"}},
+			},
+			want: ";FFMETADATA1\n" +
+				"\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=0\nEND=10000\ntitle=One\n" +
+				"\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=10000\nEND=10001\ntitle=Two\n",
+		},
+		{
+			name:       "sections without chapters",
+			transcript: &yttranscript.Transcript{Texts: []yttranscript.Text{{Start: 3, Duration: 2, Content: "hello"}}},
+			want:       ";FFMETADATA1\n\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=0\nEND=5000\ntitle=Section 1: hello\n",
+		},
+		{
+			name:       "empty",
+			transcript: &yttranscript.Transcript{},
+			want:       ";FFMETADATA1\n",
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := WriteFFMetadata(&buf, tt.transcript); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, buf.String(), tt.want)
+		}
+	}
+}
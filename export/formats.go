@@ -0,0 +1,52 @@
+package export
+
+import (
+	"io"
+	"sort"
+
+	"yt-transcript/yttranscript"
+)
+
+// WriterFunc renders a transcript to w in a particular format.
+type WriterFunc func(w io.Writer, transcript *yttranscript.Transcript) error
+
+// Formats maps format names accepted by the command-line tool to writers.
+var Formats = map[string]WriterFunc{
+	"ass":        WriteASS,
+	"audacity":   WriteAudacityLabels,
+	"csv":        WriteCSV,
+	"ffmetadata": WriteFFMetadata,
+	"json":       WriteJSON,
+	"markdown":   WriteMarkdown,
+	"speakers":   WriteSpeakers,
+	"tsv":        WriteTSV,
+	"whisper":    WriteWhisperJSON,
+}
+
+// FormatNames returns the names of all registered formats in sorted order.
+func FormatNames() []string {
+	names := make([]string, 0, len(Formats))
+	for name := range Formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// extensions maps format names to file extensions where they differ.
+var extensions = map[string]string{
+	"audacity":   "txt",
+	"ffmetadata": "txt",
+	"markdown":   "md",
+	"speakers":   "txt",
+	"whisper":    "json",
+}
+
+// Extension returns the file extension, without a dot, for files in the
+// named format.
+func Extension(format string) string {
+	if ext, ok := extensions[format]; ok {
+		return ext
+	}
+	return format
+}
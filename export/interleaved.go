@@ -0,0 +1,31 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"yt-transcript/yttranscript"
+)
+
+// WriteInterleaved writes a transcript and its translation to w cue by cue:
+// each timestamped original line is followed by its translation, with a blank
+// line between cues. This is the layout language learners read side by side.
+func WriteInterleaved(w io.Writer, pair *yttranscript.TranscriptPair) error {
+	original, translated := pair.Original.Texts, pair.Translated.Texts
+	for i, text := range original {
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		translation := ""
+		if i < len(translated) {
+			translation = translated[i].Content
+		}
+		_, err := fmt.Fprintf(w, "[%s] %s\n        %s\n", yttranscript.FormatTimestamp(text.Start), text.Content, translation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
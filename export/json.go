@@ -0,0 +1,94 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"yt-transcript/yttranscript"
+)
+
+// JSONTranscript is the document written by WriteJSON.
+type JSONTranscript struct {
+	VideoID  string        `json:"video_id"`
+	Language string        `json:"language"`
+	Title    string        `json:"title,omitempty"`
+	Segments []JSONSegment `json:"segments"`
+
+	// Set when the transcript was substituted for a missing language.
+	RequestedLanguage string `json:"requested_language,omitempty"`
+	Substitution      string `json:"substitution,omitempty"`
+
+	// Where and when the transcript was fetched. Absent for transcripts not
+	// fetched from YouTube, such as those read from a file.
+	Provenance *yttranscript.Provenance `json:"provenance,omitempty"`
+
+	// Only present when Options.Annotate is set.
+	Automatic      *bool  `json:"automatic_captions,omitempty"`
+	TranslatedFrom string `json:"translated_from,omitempty"`
+	Note           string `json:"note,omitempty"`
+}
+
+// JSONSegment is one line of text in a JSONTranscript. The pointer fields are
+// computed and only present when Options.ComputedFields is set.
+type JSONSegment struct {
+	Index    *int     `json:"index,omitempty"`
+	Start    float64  `json:"start"`
+	End      *float64 `json:"end,omitempty"`
+	Duration float64  `json:"duration"`
+	Text     string   `json:"text"`
+	Chars    *int     `json:"chars,omitempty"`
+	Words    *int     `json:"words,omitempty"`
+}
+
+// WriteJSON writes the transcript to w as a JSON document with one entry per
+// line of text.
+func WriteJSON(w io.Writer, transcript *yttranscript.Transcript) error {
+	return writeJSON(w, transcript, Options{})
+}
+
+func writeJSON(w io.Writer, transcript *yttranscript.Transcript, opts Options) error {
+	out := JSONTranscript{
+		VideoID:  transcript.VideoID,
+		Language: transcript.LanguageCode,
+		Title:    transcript.Title,
+		Segments: make([]JSONSegment, len(transcript.Texts)),
+
+		RequestedLanguage: transcript.Provenance.RequestedLanguage,
+		Substitution:      string(transcript.Provenance.Substitution),
+		Provenance:        provenance(transcript),
+	}
+	if opts.Annotate {
+		automatic := transcript.Provenance.Kind == "asr"
+		out.Automatic = &automatic
+		out.TranslatedFrom = transcript.Provenance.TranslatedFrom
+		out.Note = machineNote(transcript)
+	}
+	for i, text := range transcript.Texts {
+		segment := JSONSegment{
+			Start:    text.Start,
+			Duration: text.Duration,
+			Text:     text.Content,
+		}
+		if opts.ComputedFields {
+			index, end := i, roundTime(text.End())
+			chars, words := utf8.RuneCountInString(text.Content), len(strings.Fields(text.Content))
+			segment.Index, segment.End, segment.Chars, segment.Words = &index, &end, &chars, &words
+		}
+		out.Segments[i] = segment
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// provenance returns the transcript's provenance, or nil if it has none.
+func provenance(transcript *yttranscript.Transcript) *yttranscript.Provenance {
+	if transcript.Provenance == (yttranscript.Provenance{}) {
+		return nil
+	}
+	p := transcript.Provenance
+	return &p
+}
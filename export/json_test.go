@@ -0,0 +1,57 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestProvenance(t *testing.T) {
+	fetched := &yttranscript.Transcript{
+		VideoID:      "dQw4w9WgXcQ",
+		LanguageCode: "en",
+		Texts:        []yttranscript.Text{{Start: 1, Duration: 2, Content: "hello"}},
+		Provenance: yttranscript.Provenance{
+			VideoID:      "dQw4w9WgXcQ",
+			TrackID:      "a.en",
+			TrackName:    "English (auto-generated)",
+			LanguageCode: "en",
+			Kind:         "asr",
+			FetchedAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			ToolVersion:  "v1.2.3",
+		},
+	}
+	local := &yttranscript.Transcript{LanguageCode: "en", Texts: fetched.Texts}
+
+	writers := map[string]func(io.Writer, *yttranscript.Transcript) error{
+		"json":    WriteJSON,
+		"whisper": WriteWhisperJSON,
+	}
+	for name, write := range writers {
+		var buf bytes.Buffer
+		if err := write(&buf, fetched); err != nil {
+			t.Fatal(err)
+		}
+		var doc struct {
+			Provenance *yttranscript.Provenance `json:"provenance"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+		if doc.Provenance == nil || *doc.Provenance != fetched.Provenance {
+			t.Errorf("%s provenance = %+v, want %+v", name, doc.Provenance, fetched.Provenance)
+		}
+
+		buf.Reset()
+		if err := write(&buf, local); err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(buf.Bytes(), []byte(`"provenance"`)) {
+			t.Errorf("%s output has provenance for a transcript without one:\n%s", name, buf.String())
+		}
+	}
+}
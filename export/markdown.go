@@ -0,0 +1,103 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// markdownParagraphSeconds is how long a paragraph may run before a new one,
+// with its own timestamp link, is started.
+const markdownParagraphSeconds = 30
+
+// WriteMarkdown writes the transcript to w as a Markdown document. The video
+// title becomes the top-level heading, chapters become second-level headings,
+// and every paragraph starts with a timestamp linking to that point in the
+// video. With Options.Sections, videos without chapters get a second-level
+// heading per topical section instead.
+func WriteMarkdown(w io.Writer, transcript *yttranscript.Transcript) error {
+	return writeMarkdown(w, transcript, Options{})
+}
+
+func writeMarkdown(w io.Writer, transcript *yttranscript.Transcript, opts Options) error {
+	var b strings.Builder
+
+	title := transcript.Title
+	if title == "" {
+		title = transcript.VideoID
+	}
+	fmt.Fprintf(&b, "# %s\n", title)
+
+	headings := transcript.Chapters
+	if opts.Sections && len(headings) == 0 {
+		// A single section would only repeat the title.
+		if sections := transcript.Segments(yttranscript.SegmentOptions{}); len(sections) > 1 {
+			headings = make([]yttranscript.Chapter, len(sections))
+			for i, section := range sections {
+				headings[i] = yttranscript.Chapter{Title: sectionTitle(i, section), Start: section.Start}
+			}
+		}
+	}
+
+	chapter := -1
+	var paragraph []string
+	var paragraphStart float64
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n[%s](%s) %s\n",
+			yttranscript.FormatTimestamp(paragraphStart),
+			yttranscript.DeepLink(transcript.VideoID, paragraphStart),
+			strings.Join(paragraph, " "))
+		paragraph = nil
+	}
+
+	for _, text := range transcript.Texts {
+		if next := chapterAt(headings, text.Start); next != chapter {
+			flush()
+			chapter = next
+			if chapter >= 0 {
+				fmt.Fprintf(&b, "\n## %s\n", headings[chapter].Title)
+			}
+		}
+		if len(paragraph) > 0 && text.Start-paragraphStart >= markdownParagraphSeconds {
+			flush()
+		}
+		if len(paragraph) == 0 {
+			paragraphStart = text.Start
+		}
+		if text.Content != "" {
+			paragraph = append(paragraph, text.Content)
+		}
+	}
+	flush()
+
+	if note := machineNote(transcript); opts.Annotate && note != "" {
+		fmt.Fprintf(&b, "\n---\n\n*%s*\n", note)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sectionTitle names the i-th topical section after its terms.
+func sectionTitle(i int, section yttranscript.Section) string {
+	if len(section.Terms) == 0 {
+		return fmt.Sprintf("Section %d", i+1)
+	}
+	return fmt.Sprintf("Section %d: %s", i+1, strings.Join(section.Terms, ", "))
+}
+
+// chapterAt returns the index of the chapter containing the given time, or -1.
+func chapterAt(chapters []yttranscript.Chapter, seconds float64) int {
+	index := -1
+	for i, chapter := range chapters {
+		if chapter.Start <= seconds {
+			index = i
+		}
+	}
+	return index
+}
@@ -0,0 +1,181 @@
+// Package objectstore uploads rendered transcripts to S3-compatible object
+// storage, including Google Cloud Storage through its XML API with HMAC keys,
+// so large corpus builds need no local disk.
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+// Well-known endpoints. EndpointS3 only serves us-east-1 directly; see
+// S3Endpoint for other regions.
+const (
+	EndpointS3  = "https://s3.amazonaws.com"
+	EndpointGCS = "https://storage.googleapis.com"
+)
+
+// S3Endpoint returns the regional Amazon S3 endpoint of region.
+func S3Endpoint(region string) string {
+	return "https://s3." + region + ".amazonaws.com"
+}
+
+// DefaultKeyTemplate names objects after the video, language and format.
+const DefaultKeyTemplate = "{{.VideoID}}/{{.Language}}.{{.Ext}}"
+
+// contentTypes maps file extensions to the Content-Type of uploaded objects.
+var contentTypes = map[string]string{
+	"ass":  "text/x-ssa; charset=utf-8",
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json",
+	"md":   "text/markdown; charset=utf-8",
+	"tsv":  "text/tab-separated-values; charset=utf-8",
+	"txt":  "text/plain; charset=utf-8",
+}
+
+// Config describes a bucket and its credentials.
+type Config struct {
+	Endpoint     string // Defaults to the S3Endpoint of Region.
+	Region       string // Defaults to "us-east-1"; use "auto" for GCS.
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string       // Token of temporary credentials, sent as X-Amz-Security-Token.
+	KeyTemplate  string       // text/template for object keys. Defaults to DefaultKeyTemplate.
+	HTTPClient   *http.Client // Defaults to http.DefaultClient.
+}
+
+// ConfigFromEnv fills the credentials and region from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN and AWS_REGION.
+func ConfigFromEnv(bucket string) Config {
+	return Config{
+		Region:       os.Getenv("AWS_REGION"),
+		Bucket:       bucket,
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// KeyData is the data object key templates are executed with.
+type KeyData struct {
+	VideoID  string
+	Language string
+	Title    string
+	Format   string
+	Ext      string
+}
+
+// Store uploads transcripts to a bucket. It is safe for concurrent use.
+type Store struct {
+	cfg      Config
+	endpoint *url.URL
+	keys     *template.Template
+}
+
+// New validates cfg and returns a Store for its bucket.
+func New(cfg Config) (*Store, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("bucket is required")
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, fmt.Errorf("access key and secret key are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = S3Endpoint(cfg.Region)
+	}
+	if cfg.KeyTemplate == "" {
+		cfg.KeyTemplate = DefaultKeyTemplate
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+	keys, err := template.New("key").Option("missingkey=error").Parse(cfg.KeyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key template: %w", err)
+	}
+	return &Store{cfg: cfg, endpoint: endpoint, keys: keys}, nil
+}
+
+// Upload renders the transcript in the named export format with opts applied
+// and stores it under the key produced by the key template, which it returns.
+func (s *Store) Upload(ctx context.Context, transcript *yttranscript.Transcript, format string, opts export.Options) (string, error) {
+	write, ok := export.Writer(format, opts)
+	if !ok {
+		return "", fmt.Errorf("unknown format %q", format)
+	}
+	var body bytes.Buffer
+	if err := write(&body, transcript); err != nil {
+		return "", fmt.Errorf("failed to render transcript: %w", err)
+	}
+
+	ext := export.Extension(format)
+	var key strings.Builder
+	if err := s.keys.Execute(&key, KeyData{
+		VideoID:  transcript.VideoID,
+		Language: transcript.LanguageCode,
+		Title:    transcript.Title,
+		Format:   format,
+		Ext:      ext,
+	}); err != nil {
+		return "", fmt.Errorf("failed to build object key: %w", err)
+	}
+
+	contentType := contentTypes[ext]
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if err := s.Put(ctx, key.String(), body.Bytes(), contentType); err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+// Put stores data under key.
+func (s *Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimRight(objectURL.Path, "/") + "/" + s.cfg.Bucket + "/" + strings.TrimLeft(key, "/")
+	// Send the path exactly as it is signed.
+	objectURL.RawPath = canonicalURI(&objectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL.String(), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.cfg.SessionToken)
+	}
+	signV4(req, s.cfg.AccessKey, s.cfg.SecretKey, s.cfg.Region, hashHex(data), time.Now())
+
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: bad status: %s: %s", key, resp.Status, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+func TestUpload(t *testing.T) {
+	transcript := &yttranscript.Transcript{
+		VideoID:      "dQw4w9WgXcQ",
+		LanguageCode: "en",
+		Texts:        []yttranscript.Text{{Start: 1, Duration: 2, Content: "hello world"}},
+	}
+	tests := []struct {
+		name         string
+		sessionToken string
+		opts         export.Options
+		wantBody     string // Substring of the uploaded object.
+	}{
+		{name: "long-term credentials", wantBody: `"text": "hello world"`},
+		{name: "session token", sessionToken: "FwoGZXIvYXdzEXAMPLE", wantBody: `"text": "hello world"`},
+		{name: "options", opts: export.Options{ComputedFields: true}, wantBody: `"words": 2`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+			}))
+			defer server.Close()
+
+			store, err := New(Config{
+				Endpoint:     server.URL,
+				Bucket:       "bucket",
+				AccessKey:    exampleAccessKey,
+				SecretKey:    exampleSecretKey,
+				SessionToken: tt.sessionToken,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := store.Upload(context.Background(), transcript, "json", tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key != "dQw4w9WgXcQ/en.json" || got.URL.Path != "/bucket/dQw4w9WgXcQ/en.json" {
+				t.Errorf("key = %s, path = %s", key, got.URL.Path)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("uploaded object lacks %s:\n%s", tt.wantBody, body)
+			}
+
+			authorization := got.Header.Get("Authorization")
+			signed := strings.Contains(authorization, "x-amz-security-token")
+			if token := got.Header.Get("X-Amz-Security-Token"); token != tt.sessionToken || signed != (tt.sessionToken != "") {
+				t.Errorf("X-Amz-Security-Token = %q, signed %v; Authorization = %s", token, signed, authorization)
+			}
+		})
+	}
+}
+
+func TestDefaultEndpoint(t *testing.T) {
+	tests := []struct {
+		region string
+		want   string
+	}{
+		{region: "", want: "https://s3.us-east-1.amazonaws.com"},
+		{region: "eu-west-1", want: "https://s3.eu-west-1.amazonaws.com"},
+	}
+	for _, tt := range tests {
+		store, err := New(Config{Region: tt.region, Bucket: "b", AccessKey: "k", SecretKey: "s"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := store.endpoint.String(); got != tt.want {
+			t.Errorf("region %q: endpoint = %s, want %s", tt.region, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// KafkaREST publishes envelopes to a Kafka topic through a Confluent REST
+// Proxy (API v2), keyed by video ID so that every transcript of a video lands
+// in the same partition.
+type KafkaREST struct {
+	topicURL string
+	client   *http.Client
+}
+
+// NewKafkaREST returns a sink posting to topicURL, such as
+// http://localhost:8082/topics/transcripts. A nil client means
+// http.DefaultClient.
+func NewKafkaREST(topicURL string, client *http.Client) *KafkaREST {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &KafkaREST{topicURL: topicURL, client: client}
+}
+
+type kafkaRecord struct {
+	Key   string    `json:"key"`
+	Value *Envelope `json:"value"`
+}
+
+type kafkaResponse struct {
+	Offsets []struct {
+		Partition int    `json:"partition"`
+		Offset    int64  `json:"offset"`
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// Publish posts the envelope as a JSON record.
+func (k *KafkaREST) Publish(ctx context.Context, envelope *Envelope) error {
+	body, err := json.Marshal(map[string][]kafkaRecord{
+		"records": {{Key: envelope.VideoID, Value: envelope}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, k.topicURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create publish request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish %s: %w", envelope.VideoID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to publish %s: bad status: %s: %s", envelope.VideoID, resp.Status, strings.TrimSpace(string(message)))
+	}
+	var result kafkaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode publish response: %w", err)
+	}
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("failed to publish %s: %s", envelope.VideoID, offset.Error)
+		}
+	}
+	return nil
+}
+
+// Close does nothing; the proxy holds no connection open.
+func (k *KafkaREST) Close() error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+func TestKafkaREST(t *testing.T) {
+	transcript := &yttranscript.Transcript{
+		VideoID:      "dQw4w9WgXcQ",
+		LanguageCode: "en",
+		Title:        "Never Gonna Give You Up",
+		Texts:        []yttranscript.Text{{Start: 1, Duration: 2, Content: "hello world"}},
+		Provenance:   yttranscript.Provenance{VideoID: "dQw4w9WgXcQ", Kind: "asr"},
+	}
+	envelope, err := NewEnvelope(transcript, "UCuAXFkgsw1L7xaCfnd5JJOw", "csv", export.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  string
+	}{
+		{name: "accepted", status: http.StatusOK, response: `{"offsets":[{"partition":0,"offset":7,"error_code":null,"error":null}]}`},
+		{name: "record rejected", status: http.StatusOK, response: `{"offsets":[{"partition":null,"offset":null,"error_code":40403,"error":"leader not available"}]}`, wantErr: "leader not available"},
+		{name: "unknown topic", status: http.StatusNotFound, response: `{"error_code":40401,"message":"Topic not found."}`, wantErr: "Topic not found."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path, contentType string
+			var body struct {
+				Records []struct {
+					Key   string   `json:"key"`
+					Value Envelope `json:"value"`
+				} `json:"records"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+				json.NewDecoder(r.Body).Decode(&body)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.response)
+			}))
+			defer server.Close()
+
+			sink, err := Open(context.Background(), server.URL+"/topics/transcripts")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Close()
+			err = sink.Publish(context.Background(), envelope)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != "/topics/transcripts" || contentType != "application/vnd.kafka.json.v2+json" {
+				t.Errorf("posted %s to %s", contentType, path)
+			}
+			if len(body.Records) != 1 {
+				t.Fatalf("got %d records, want 1", len(body.Records))
+			}
+			record := body.Records[0]
+			got := record.Value
+			if record.Key != "dQw4w9WgXcQ" || got.VideoID != "dQw4w9WgXcQ" || got.ChannelID != "UCuAXFkgsw1L7xaCfnd5JJOw" ||
+				got.Language != "en" || got.Format != "csv" || got.Provenance.Kind != "asr" || !strings.Contains(got.Content, "hello world") {
+				t.Errorf("record = %q %+v", record.Key, got)
+			}
+			if time.Since(got.PublishedAt) > time.Minute {
+				t.Errorf("published_at = %v, want now", got.PublishedAt)
+			}
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	for _, rawURL := range []string{"kafka://broker:9092/transcripts", "file:///tmp/out", "://"} {
+		if _, err := Open(context.Background(), rawURL); err == nil {
+			t.Errorf("Open(%q) succeeded, want error", rawURL)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+const (
+	natsDefaultPort = "4222"
+	// natsTimeout bounds every exchange with the server when the context
+	// has no deadline of its own.
+	natsTimeout = 30 * time.Second
+)
+
+// NATS publishes envelopes to a subject of a NATS server over the server's
+// plain-text protocol. Every message is followed by a PING, so Publish only
+// returns once the server has processed it and reports errors such as a
+// denied subject. A broken connection is dialed again on the next Publish.
+type NATS struct {
+	url     *url.URL
+	subject string
+
+	mu         sync.Mutex // Serializes exchanges on conn.
+	conn       net.Conn
+	r          *bufio.Reader
+	maxPayload int
+}
+
+// DialNATS connects to the server and subject named by a
+// nats://[user:password@]host[:port]/subject URL.
+func DialNATS(ctx context.Context, u *url.URL) (*NATS, error) {
+	subject := strings.Trim(u.Path, "/")
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid NATS subject %q", subject)
+	}
+	n := &NATS{url: u, subject: subject}
+	if err := n.connect(ctx); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// connect dials the server and completes the handshake. n.mu must be held
+// or n not yet shared.
+func (n *NATS) connect(ctx context.Context) error {
+	addr := n.url.Host
+	if n.url.Port() == "" {
+		addr = net.JoinHostPort(n.url.Hostname(), natsDefaultPort)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	n.conn, n.r = conn, bufio.NewReader(conn)
+	if err := n.handshake(ctx); err != nil {
+		n.disconnect()
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	return nil
+}
+
+func (n *NATS) handshake(ctx context.Context) error {
+	defer n.watch(ctx)()
+
+	line, err := n.readLine()
+	if err != nil {
+		return err
+	}
+	infoJSON, ok := strings.CutPrefix(line, "INFO ")
+	if !ok {
+		return fmt.Errorf("unexpected greeting %q", line)
+	}
+	var info struct {
+		TLSRequired bool `json:"tls_required"`
+		MaxPayload  int  `json:"max_payload"`
+	}
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return fmt.Errorf("failed to decode server info: %w", err)
+	}
+	if info.TLSRequired {
+		return errors.New("server requires TLS, which is not supported")
+	}
+	n.maxPayload = info.MaxPayload
+
+	options := map[string]any{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "yt-transcript",
+		"lang":     "go",
+		"version":  yttranscript.Version,
+		"protocol": 0,
+	}
+	if user := n.url.User; user != nil {
+		if password, ok := user.Password(); ok {
+			options["user"], options["pass"] = user.Username(), password
+		} else {
+			options["auth_token"] = user.Username()
+		}
+	}
+	connect, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(n.conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+	return n.awaitPong()
+}
+
+// Publish sends the envelope as JSON to the subject.
+func (n *NATS) Publish(ctx context.Context, envelope *Envelope) error {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode envelope: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+	if n.maxPayload > 0 && len(data) > n.maxPayload {
+		return fmt.Errorf("failed to publish %s: message of %d bytes exceeds the server's limit of %d", envelope.VideoID, len(data), n.maxPayload)
+	}
+
+	stop := n.watch(ctx)
+	defer stop()
+	msg := fmt.Appendf(nil, "PUB %s %d\r\n", n.subject, len(data))
+	msg = append(append(msg, data...), "\r\nPING\r\n"...)
+	if _, err = n.conn.Write(msg); err == nil {
+		err = n.awaitPong()
+	}
+	if err != nil {
+		// The stream may be out of step with the server; start over.
+		n.disconnect()
+		return fmt.Errorf("failed to publish %s: %w", envelope.VideoID, err)
+	}
+	return nil
+}
+
+// Close closes the connection.
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conn == nil {
+		return nil
+	}
+	return n.disconnect()
+}
+
+func (n *NATS) disconnect() error {
+	err := n.conn.Close()
+	n.conn, n.r = nil, nil
+	return err
+}
+
+// watch bounds the exchange that follows by ctx's deadline, or natsTimeout,
+// and aborts it when ctx is canceled. The returned function ends the watch.
+func (n *NATS) watch(ctx context.Context) func() {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(natsTimeout)
+	}
+	conn := n.conn
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Unix(1, 0)) })
+	return func() {
+		stop()
+		conn.SetDeadline(time.Time{})
+	}
+}
+
+// awaitPong reads until the server's PONG, answering its PINGs and
+// returning its -ERR messages as errors.
+func (n *NATS) awaitPong() error {
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("server error: %s", strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'"))
+		default:
+			return fmt.Errorf("unexpected server message %q", line)
+		}
+	}
+}
+
+func (n *NATS) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,186 @@
+package sink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+// fakeNATS is a NATS server speaking enough of the protocol to accept
+// publishes. It denies publishing to the subject "denied" and closes each
+// connection once it acknowledged closeAfter messages, when that is set.
+type fakeNATS struct {
+	listener   net.Listener
+	info       string
+	closeAfter int
+	connects   chan map[string]any
+	messages   chan string
+}
+
+func newFakeNATS(t *testing.T, info string) *fakeNATS {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeNATS{listener: listener, info: info, connects: make(chan map[string]any, 10), messages: make(chan string, 10)}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeNATS) url(userinfo, subject string) *url.URL {
+	u, _ := url.Parse("nats://" + userinfo + s.listener.Addr().String() + "/" + subject)
+	return u
+}
+
+func (s *fakeNATS) serve(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO %s\r\n", s.info)
+	r := bufio.NewReader(conn)
+	published := 0
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "CONNECT":
+			var options map[string]any
+			json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &options)
+			s.connects <- options
+		case "PING":
+			io.WriteString(conn, "PONG\r\n")
+			if s.closeAfter > 0 && published == s.closeAfter {
+				return
+			}
+		case "PUB":
+			size, _ := strconv.Atoi(fields[2])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			if fields[1] == "denied" {
+				fmt.Fprintf(conn, "-ERR 'Permissions Violation for Publish to \"%s\"'\r\n", fields[1])
+				continue
+			}
+			s.messages <- string(payload[:size])
+			published++
+		}
+	}
+}
+
+func TestNATS(t *testing.T) {
+	envelope := &Envelope{VideoID: "dQw4w9WgXcQ", Language: "en", Format: "csv", Content: "hello"}
+	tests := []struct {
+		name     string
+		userinfo string
+		want     map[string]string
+	}{
+		{name: "anonymous", want: map[string]string{"name": "yt-transcript"}},
+		{name: "user and password", userinfo: "alice:secret@", want: map[string]string{"user": "alice", "pass": "secret"}},
+		{name: "token", userinfo: "s3cr3t@", want: map[string]string{"auth_token": "s3cr3t"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeNATS(t, `{"max_payload":1048576}`)
+			n, err := DialNATS(context.Background(), server.url(tt.userinfo, "transcripts.en"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer n.Close()
+			options := <-server.connects
+			for key, want := range tt.want {
+				if options[key] != want {
+					t.Errorf("CONNECT %s = %v, want %q", key, options[key], want)
+				}
+			}
+			if options["verbose"] != false {
+				t.Errorf("CONNECT verbose = %v, want false", options["verbose"])
+			}
+
+			if err := n.Publish(context.Background(), envelope); err != nil {
+				t.Fatal(err)
+			}
+			var got Envelope
+			if err := json.Unmarshal([]byte(<-server.messages), &got); err != nil || got.VideoID != envelope.VideoID || got.Content != envelope.Content {
+				t.Errorf("published %+v, %v; want %+v", got, err, envelope)
+			}
+		})
+	}
+}
+
+func TestNATSErrors(t *testing.T) {
+	envelope := &Envelope{VideoID: "dQw4w9WgXcQ", Content: strings.Repeat("x", 100)}
+
+	server := newFakeNATS(t, `{}`)
+	n, err := DialNATS(context.Background(), server.url("", "denied"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Publish(context.Background(), envelope); err == nil || !strings.Contains(err.Error(), "Permissions Violation") {
+		t.Errorf("publish to a denied subject: err = %v", err)
+	}
+	n.Close()
+
+	server = newFakeNATS(t, `{"max_payload":10}`)
+	if n, err = DialNATS(context.Background(), server.url("", "transcripts")); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Publish(context.Background(), envelope); err == nil || !strings.Contains(err.Error(), "limit") {
+		t.Errorf("oversized message: err = %v", err)
+	}
+	n.Close()
+
+	server = newFakeNATS(t, `{"tls_required":true}`)
+	if _, err := DialNATS(context.Background(), server.url("", "transcripts")); err == nil {
+		t.Error("server requiring TLS: got no error")
+	}
+
+	if _, err := DialNATS(context.Background(), &url.URL{Scheme: "nats", Host: "127.0.0.1:1", Path: "/"}); err == nil {
+		t.Error("empty subject: got no error")
+	}
+}
+
+func TestNATSReconnect(t *testing.T) {
+	server := newFakeNATS(t, `{}`)
+	server.closeAfter = 1
+	n, err := DialNATS(context.Background(), server.url("", "transcripts"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer n.Close()
+	for _, id := range []string{"first", "second", "third"} {
+		envelope := &Envelope{VideoID: id, Provenance: yttranscript.Provenance{VideoID: id}}
+		// The server drops the connection after each message, so a publish
+		// may fail once before the next one dials again.
+		if err := n.Publish(context.Background(), envelope); err != nil {
+			if err := n.Publish(context.Background(), envelope); err != nil {
+				t.Fatalf("%s: %v", id, err)
+			}
+		}
+		var got Envelope
+		json.Unmarshal([]byte(<-server.messages), &got)
+		if got.VideoID != id {
+			t.Errorf("published %q, want %q", got.VideoID, id)
+		}
+	}
+}
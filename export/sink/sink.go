@@ -0,0 +1,85 @@
+// Package sink publishes transcripts as messages to streaming platforms, so
+// that batch commands can feed data pipelines instead of writing files. NATS
+// is spoken directly over TCP and Kafka through a Confluent REST Proxy, so
+// neither needs a client library.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+// Sink publishes transcript envelopes. Implementations are safe for
+// concurrent use.
+type Sink interface {
+	// Publish sends the envelope and returns once the broker accepted it.
+	Publish(ctx context.Context, envelope *Envelope) error
+	// Close releases the connection.
+	Close() error
+}
+
+// Envelope is the JSON message published for each transcript: the
+// transcript rendered in an export format, with metadata for routing and
+// deduplication on the consumer side.
+type Envelope struct {
+	VideoID     string                  `json:"video_id"`
+	ChannelID   string                  `json:"channel_id,omitempty"`
+	Language    string                  `json:"language"`
+	Title       string                  `json:"title,omitempty"`
+	Format      string                  `json:"format"`
+	Provenance  yttranscript.Provenance `json:"provenance"`
+	Warnings    []string                `json:"warnings,omitempty"`
+	PublishedAt time.Time               `json:"published_at"`
+	Content     string                  `json:"content"` // The transcript in Format.
+}
+
+// NewEnvelope renders the transcript in the named export format with opts
+// applied and wraps it with its metadata.
+func NewEnvelope(transcript *yttranscript.Transcript, channelID, format string, opts export.Options) (*Envelope, error) {
+	write, ok := export.Writer(format, opts)
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	var content bytes.Buffer
+	if err := write(&content, transcript); err != nil {
+		return nil, fmt.Errorf("failed to render transcript: %w", err)
+	}
+	return &Envelope{
+		VideoID:     transcript.VideoID,
+		ChannelID:   channelID,
+		Language:    transcript.LanguageCode,
+		Title:       transcript.Title,
+		Format:      format,
+		Provenance:  transcript.Provenance,
+		Warnings:    transcript.Warnings,
+		PublishedAt: time.Now().UTC(),
+		Content:     content.String(),
+	}, nil
+}
+
+// Open connects to the sink named by rawURL:
+//
+//   - nats://[user:password@]host[:port]/subject publishes to a NATS subject.
+//     A user without a password is sent as an auth token.
+//   - http(s)://host[:port]/topics/name posts to a topic of a Kafka REST
+//     Proxy, keyed by video ID.
+func Open(ctx context.Context, rawURL string) (Sink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sink URL: %w", err)
+	}
+	switch u.Scheme {
+	case "nats":
+		return DialNATS(ctx, u)
+	case "http", "https":
+		return NewKafkaREST(u.String(), nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported sink scheme %q, expected nats, http or https", u.Scheme)
+	}
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// WriteSpeakers writes the transcript to w as one paragraph per speaker turn,
+// each starting with the turn's timestamp and speaker name.
+func WriteSpeakers(w io.Writer, transcript *yttranscript.Transcript) error {
+	for i, turn := range transcript.SpeakerTurns() {
+		parts := make([]string, 0, len(turn.Texts))
+		for _, text := range turn.Texts {
+			if text.Content != "" {
+				parts = append(parts, text.Content)
+			}
+		}
+
+		speaker := turn.Speaker
+		if speaker == "" {
+			speaker = "Unknown"
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, "\n"); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "[%s] %s: %s\n", yttranscript.FormatTimestamp(turn.Start()), speaker, strings.Join(parts, " "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package export renders transcripts into formats consumed by other tools.
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strings"
+
+	"yt-transcript/yttranscript"
+)
+
+// WhisperTranscript mirrors the JSON document produced by OpenAI Whisper.
+type WhisperTranscript struct {
+	Text     string           `json:"text"`
+	Segments []WhisperSegment `json:"segments"`
+	Language string           `json:"language"`
+
+	// Provenance is an addition to Whisper's schema recording where and when
+	// the transcript was fetched.
+	Provenance *yttranscript.Provenance `json:"provenance,omitempty"`
+}
+
+// WhisperSegment is a single timed segment in a WhisperTranscript. The
+// decoder statistics Whisper reports have no YouTube equivalent and are
+// filled with neutral values so that strict consumers accept the document.
+type WhisperSegment struct {
+	ID               int           `json:"id"`
+	Seek             int           `json:"seek"`
+	Start            float64       `json:"start"`
+	End              float64       `json:"end"`
+	Text             string        `json:"text"`
+	Tokens           []int         `json:"tokens"`
+	Temperature      float64       `json:"temperature"`
+	AvgLogprob       float64       `json:"avg_logprob"`
+	CompressionRatio float64       `json:"compression_ratio"`
+	NoSpeechProb     float64       `json:"no_speech_prob"`
+	Words            []WhisperWord `json:"words"`
+}
+
+// whisperWindowFrames is the size of Whisper's 30 second decoding window in
+// 10ms mel frames, the unit of WhisperSegment.Seek.
+const whisperWindowFrames = 3000
+
+// WhisperWord is a single word with its own timing inside a WhisperSegment.
+type WhisperWord struct {
+	Word        string  `json:"word"`
+	Start       float64 `json:"start"`
+	End         float64 `json:"end"`
+	Probability float64 `json:"probability"`
+}
+
+// ToWhisper converts a transcript into the Whisper JSON schema.
+// YouTube only provides timing per caption line, so word timings are
+// interpolated across each line in proportion to word length.
+func ToWhisper(transcript *yttranscript.Transcript) *WhisperTranscript {
+	out := &WhisperTranscript{
+		Segments:   make([]WhisperSegment, 0, len(transcript.Texts)),
+		Language:   transcript.LanguageCode,
+		Provenance: provenance(transcript),
+	}
+
+	var fullText strings.Builder
+	for i, text := range transcript.Texts {
+		segmentText := " " + text.Content
+		fullText.WriteString(segmentText)
+		out.Segments = append(out.Segments, WhisperSegment{
+			ID:               i,
+			Seek:             int(text.Start*100) / whisperWindowFrames * whisperWindowFrames,
+			Start:            roundTime(text.Start),
+			End:              roundTime(text.End()),
+			Text:             segmentText,
+			Tokens:           []int{},
+			CompressionRatio: 1,
+			Words:            whisperWords(text),
+		})
+	}
+	out.Text = fullText.String()
+
+	return out
+}
+
+// WriteWhisperJSON writes the transcript to w in the Whisper JSON schema.
+func WriteWhisperJSON(w io.Writer, transcript *yttranscript.Transcript) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ToWhisper(transcript))
+}
+
+func whisperWords(text yttranscript.Text) []WhisperWord {
+	words := text.Words(nil)
+	out := make([]WhisperWord, len(words))
+	for i, word := range words {
+		out[i] = WhisperWord{
+			Word:        " " + word.Text,
+			Start:       roundTime(word.Start),
+			End:         roundTime(word.End),
+			Probability: 1,
+		}
+	}
+	return out
+}
+
+// roundTime rounds seconds to milliseconds, hiding floating point noise from
+// the interpolation.
+func roundTime(seconds float64) float64 {
+	return math.Round(seconds*1000) / 1000
+}
@@ -0,0 +1,305 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"yt-transcript/export"
+	"yt-transcript/export/objectstore"
+	"yt-transcript/export/sink"
+	"yt-transcript/yttranscript"
+)
+
+// runFeed fetches transcripts for the uploads in a channel's RSS feed that
+// are not yet listed in the state file.
+func runFeed(args []string) {
+	fs := flag.NewFlagSet("feed", flag.ExitOnError)
+	flags := registerFeedFlags(fs)
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+	interrupt = watchInterrupts()
+	job := flags.job(fs.Arg(0), fs.Arg(1))
+	if *flags.dryRun {
+		job.printEstimate(0)
+		return
+	}
+	err := job.poll()
+	job.close()
+	if err != nil {
+		log.Fatalf("Failed to get channel feed: %v", err)
+	}
+	exitIfInterrupted()
+}
+
+// feedFlags are the flags shared by the feed and watch commands.
+type feedFlags struct {
+	outDir     *string
+	bucket     *string
+	sinkURL    *string
+	statePath  *string
+	format     *string
+	substitute *string
+	giveUp     *time.Duration
+	nice       *bool
+	dryRun     *bool
+}
+
+func registerFeedFlags(fs *flag.FlagSet) *feedFlags {
+	return &feedFlags{
+		outDir:     fs.String("out", ".", "directory transcripts are written to"),
+		bucket:     fs.String("bucket", "", "upload transcripts to this S3 bucket instead of writing files"),
+		sinkURL:    fs.String("sink", "", "publish transcripts to this nats://host/subject or Kafka REST Proxy topic URL instead of writing files"),
+		statePath:  fs.String("state", "", "file of video IDs already fetched (default <out>/.seen)"),
+		format:     fs.String("format", "json", "export format: "+strings.Join(export.FormatNames(), ", ")),
+		substitute: fs.String("substitute", "fail", "what to deliver when the language is missing: fail, default, translate or any-manual"),
+		giveUp:     fs.Duration("give-up", 48*time.Hour, "stop waiting for captions on videos published longer ago than this"),
+		nice:       registerNiceFlag(fs),
+		dryRun:     fs.Bool("dry-run", false, "only estimate the transcript volume, requests and time of fetching the new uploads"),
+	}
+}
+
+// job validates the flags and returns the job they describe, exiting on
+// invalid values.
+func (f *feedFlags) job(channelID, languageCode string) *feedJob {
+	policy, err := yttranscript.ParseLanguagePolicy(*f.substitute)
+	if err != nil {
+		log.Fatalf("Invalid -substitute: %v", err)
+	}
+	write, ok := export.Formats[*f.format]
+	if !ok {
+		log.Fatalf("Unknown format %q, expected one of: %s", *f.format, strings.Join(export.FormatNames(), ", "))
+	}
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	job := &feedJob{
+		client:       client,
+		channelID:    channelID,
+		languageCode: languageCode,
+		statePath:    *f.statePath,
+		policy:       policy,
+		giveUp:       *f.giveUp,
+		pacer:        newPacer(*f.nice),
+		format:       *f.format,
+		nice:         *f.nice,
+	}
+	if job.statePath == "" {
+		job.statePath = filepath.Join(*f.outDir, ".seen")
+	}
+	if *f.dryRun {
+		// Nothing is written, so leave the outputs alone.
+		return job
+	}
+	if err := os.MkdirAll(filepath.Dir(job.statePath), 0o755); err != nil {
+		log.Fatalf("Failed to create state directory: %v", err)
+	}
+
+	if *f.bucket != "" && *f.sinkURL != "" {
+		log.Fatal("Only one of -bucket and -sink can be used")
+	}
+
+	if *f.sinkURL != "" {
+		out, err := sink.Open(interrupt, *f.sinkURL)
+		if err != nil {
+			log.Fatalf("Failed to open sink: %v", err)
+		}
+		job.sink = out
+		format := *f.format
+		job.save = func(transcript *yttranscript.Transcript) (string, error) {
+			envelope, err := sink.NewEnvelope(transcript, channelID, format, export.Options{})
+			if err != nil {
+				return "", err
+			}
+			return sinkLocation(*f.sinkURL, transcript.VideoID), out.Publish(interrupt, envelope)
+		}
+		return job
+	}
+
+	if *f.bucket != "" {
+		store, err := objectstore.New(objectstore.ConfigFromEnv(*f.bucket))
+		if err != nil {
+			log.Fatalf("Failed to configure object store: %v", err)
+		}
+		format, bucket := *f.format, *f.bucket
+		job.save = func(transcript *yttranscript.Transcript) (string, error) {
+			key, err := store.Upload(interrupt, transcript, format, export.Options{})
+			return fmt.Sprintf("s3://%s/%s", bucket, key), err
+		}
+		return job
+	}
+
+	if err := os.MkdirAll(*f.outDir, 0o755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	outDir, ext := *f.outDir, export.Extension(*f.format)
+	job.save = func(transcript *yttranscript.Transcript) (string, error) {
+		path := filepath.Join(outDir, transcript.VideoID+"."+ext)
+		return path, writeTranscriptFile(path, write, transcript)
+	}
+	return job
+}
+
+// feedJob fetches the transcripts of a channel's new uploads.
+type feedJob struct {
+	client       *yttranscript.Client
+	channelID    string
+	languageCode string
+	statePath    string
+	policy       yttranscript.LanguagePolicy
+	giveUp       time.Duration
+	pacer        *pacer
+	format       string
+	nice         bool
+
+	// save stores a transcript and returns where it went.
+	save func(*yttranscript.Transcript) (string, error)
+	sink sink.Sink // Set when save publishes to a sink.
+}
+
+// close releases the job's sink connection, if any.
+func (j *feedJob) close() {
+	if j.sink != nil {
+		if err := j.sink.Close(); err != nil {
+			log.Printf("Warning: failed to close sink: %v", err)
+		}
+	}
+}
+
+// sinkLocation names a published transcript for the log, without the
+// sink's credentials.
+func sinkLocation(rawURL, videoID string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		rawURL = u.Redacted()
+	}
+	return rawURL + " " + videoID
+}
+
+// poll reads the feed once and fetches every upload not in the state file.
+// Uploads whose transcript cannot be fetched yet are left for the next poll
+// until they are older than giveUp. Only feed and state file errors are
+// returned; failures of single videos are logged. After a stop signal, poll
+// returns without starting another video.
+func (j *feedJob) poll() error {
+	fresh, err := j.freshEntries()
+	if err != nil {
+		return err
+	}
+	j.pacer.reset()
+	for _, entry := range fresh {
+		if interrupt.Err() != nil || !j.pacer.wait() {
+			return nil
+		}
+		transcript, err := j.client.GetTranscript(entry.VideoID, j.languageCode, yttranscript.WithLanguagePolicy(j.policy))
+		j.pacer.done()
+		if err != nil {
+			// New uploads often get their automatic captions later, so the
+			// video is left out of the state file and retried next poll.
+			if entry.Published.IsZero() || time.Since(entry.Published) < j.giveUp {
+				log.Printf("Warning: %s (%s): %v; will retry", entry.VideoID, entry.Title, err)
+				continue
+			}
+			log.Printf("Warning: %s (%s): %v; giving up", entry.VideoID, entry.Title, err)
+		} else {
+			for _, warning := range transcript.Warnings {
+				log.Printf("Warning (%s): %s", entry.VideoID, warning)
+			}
+			location, err := j.save(transcript)
+			if err != nil {
+				log.Printf("Warning: failed to save transcript (%s): %v", entry.VideoID, err)
+				continue
+			}
+			fmt.Println(location)
+		}
+		if err := appendSeen(j.statePath, entry.VideoID); err != nil {
+			return fmt.Errorf("failed to update state file: %w", err)
+		}
+	}
+	return nil
+}
+
+// freshEntries reads the feed and returns the uploads not in the state file,
+// oldest first.
+func (j *feedJob) freshEntries() ([]yttranscript.FeedEntry, error) {
+	seen, err := readSeen(j.statePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	entries, err := j.client.GetChannelFeed(j.channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fresh []yttranscript.FeedEntry
+	for _, entry := range entries {
+		if !seen[entry.VideoID] {
+			fresh = append(fresh, entry)
+		}
+	}
+	if len(seen) > 0 && len(fresh) >= yttranscript.FeedSize {
+		log.Printf("Warning: all %d feed entries are new; uploads since the last run may have been missed", len(fresh))
+	}
+
+	// The feed is newest first; fetch oldest first so the state file grows
+	// in upload order.
+	slices.Reverse(fresh)
+	return fresh, nil
+}
+
+// readSeen loads the video IDs in the state file, one per line. A missing
+// file means nothing has been fetched yet.
+func readSeen(path string) (map[string]bool, error) {
+	seen := make(map[string]bool)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return seen, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			seen[id] = true
+		}
+	}
+	return seen, scanner.Err()
+}
+
+func appendSeen(path, videoID string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, videoID); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func writeTranscriptFile(path string, write export.WriterFunc, transcript *yttranscript.Transcript) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, transcript); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
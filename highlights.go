@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"yt-transcript/analyze"
+	"yt-transcript/yttranscript"
+)
+
+// runHighlights prints a ranked list of candidate clips for a video.
+func runHighlights(args []string) {
+	fs := flag.NewFlagSet("highlights", flag.ExitOnError)
+	limit := fs.Int("n", 5, "number of highlights to suggest")
+	window := fs.Duration("window", 30*time.Second, "length of each highlight")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+	if *window < minWindow {
+		log.Fatalf("-window must be at least %v", minWindow)
+	}
+	videoID, languageCode := videoIDArg(fs.Arg(0)), fs.Arg(1)
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+	heatmap, err := client.GetHeatmap(videoID)
+	if err != nil {
+		log.Printf("Warning: failed to get replay heatmap: %v", err)
+	}
+
+	highlights := analyze.Highlights(transcript, analyze.HighlightOptions{Window: *window, Limit: *limit, Heatmap: heatmap})
+	for i, h := range highlights {
+		fmt.Printf("%d. [%s-%s] score %.2f", i+1, yttranscript.FormatTimestamp(h.Start), yttranscript.FormatTimestamp(h.End), h.Score)
+		if h.Chapter != "" {
+			fmt.Printf("  (%s)", h.Chapter)
+		}
+		fmt.Printf("\n   %s\n", h.Snippet)
+	}
+}
@@ -0,0 +1,316 @@
+// Package httpcache provides an http.RoundTripper that keeps GET responses on
+// disk and revalidates them with ETag and Last-Modified, so repeated fetches
+// of the same watch pages and caption tracks cost little bandwidth.
+package httpcache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"yt-transcript/internal/atomicfile"
+)
+
+// DefaultMaxBytes is the size cap used when none is given.
+const DefaultMaxBytes = 512 << 20
+
+// storedHeader records when a response was stored, for TTL checks.
+const storedHeader = "X-Httpcache-Stored"
+
+// varyHeaderPrefix prefixes the request headers recorded with an entry
+// because its response names them in Vary.
+const varyHeaderPrefix = "X-Httpcache-Vary-"
+
+// keyHeaders are the request headers that select different responses, and so
+// different entries, whether or not the server lists them in Vary: the
+// caption language and the signed-in user.
+var keyHeaders = []string{"Accept-Language", "Authorization", "Cookie"}
+
+// volatileParams are the timedtext query parameters that change with every
+// watch page load without changing the captions served: the URL's expiry,
+// the session id and the signature over them.
+var volatileParams = []string{"expire", "ei", "signature", "sparams"}
+
+// Transport is a caching http.RoundTripper. Cached responses are revalidated
+// with a conditional request unless they are younger than TTL; when the
+// server answers 304 Not Modified the stored body is served. Responses
+// without an ETag or Last-Modified header are only reused within TTL.
+// Entries are kept per URL and keyHeaders, and a response is only reused
+// for requests matching it in the headers its Vary header names. Caption
+// track URLs are keyed without their expiry and signature parameters.
+//
+// Errors reading or writing the cache never fail a request; the cache is
+// bypassed instead. A Transport is safe for concurrent use.
+type Transport struct {
+	Base     http.RoundTripper // Defaults to http.DefaultTransport.
+	Dir      string
+	MaxBytes int64         // Total size cap of Dir. Defaults to DefaultMaxBytes.
+	TTL      time.Duration // Age below which entries are served without revalidation.
+
+	// CachePost reports whether a POST request only reads data, like an API
+	// query, so that its response may be reused within TTL for requests
+	// with the same URL and body. POST requests are not cached when it is
+	// nil or TTL is zero.
+	CachePost func(*http.Request) bool
+
+	mu sync.Mutex // Serializes eviction.
+}
+
+// New returns a Transport caching in dir on top of base.
+func New(dir string, base http.RoundTripper) (*Transport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Transport{Base: base, Dir: dir}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && t.TTL > 0 && t.CachePost != nil && t.CachePost(req) {
+		return t.roundTripPost(req)
+	}
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.base().RoundTrip(req)
+	}
+
+	path := t.path(req, nil)
+	cached, stored, err := t.load(path, req)
+	if err != nil {
+		return t.fetch(req, path)
+	}
+	if t.TTL > 0 && time.Since(stored) < t.TTL {
+		t.touch(path)
+		return cached, nil
+	}
+
+	etag, lastModified := cached.Header.Get("ETag"), cached.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		cached.Body.Close()
+		return t.fetch(req, path)
+	}
+
+	conditional := req.Clone(req.Context())
+	if etag != "" {
+		conditional.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		conditional.Header.Set("If-Modified-Since", lastModified)
+	}
+	resp, err := t.base().RoundTrip(conditional)
+	if err != nil {
+		cached.Body.Close()
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		t.touch(path)
+		return cached, nil
+	}
+	cached.Body.Close()
+	return t.store(req, resp, path)
+}
+
+// roundTripPost serves a POST request from an entry younger than TTL, keyed
+// by its body as well, or sends it and stores the response. Stored POST
+// responses are never revalidated.
+func (t *Transport) roundTripPost(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = data
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	path := t.path(req, body)
+	if cached, stored, err := t.load(path, req); err == nil {
+		if time.Since(stored) < t.TTL {
+			t.touch(path)
+			return cached, nil
+		}
+		cached.Body.Close()
+	}
+	return t.fetch(req, path)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base == nil {
+		return http.DefaultTransport
+	}
+	return t.Base
+}
+
+// path returns the cache file of the request's URL, keyHeaders and, for
+// POST requests, body.
+func (t *Transport) path(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	io.WriteString(hash, cacheKeyURL(req.URL))
+	for _, name := range keyHeaders {
+		fmt.Fprintf(hash, "\n%s: %s", name, strings.Join(req.Header.Values(name), ", "))
+	}
+	if req.Method != http.MethodGet {
+		fmt.Fprintf(hash, "\n%s\n", req.Method)
+		hash.Write(body)
+	}
+	return filepath.Join(t.Dir, hex.EncodeToString(hash.Sum(nil)))
+}
+
+// cacheKeyURL returns u with the volatileParams of caption track URLs
+// removed, so the same track fetched through a fresh watch page hits the
+// entry stored for the last one.
+func cacheKeyURL(u *url.URL) string {
+	if !strings.HasSuffix(u.Path, "/timedtext") {
+		return u.String()
+	}
+	query := u.Query()
+	for _, name := range volatileParams {
+		query.Del(name)
+	}
+	key := *u
+	key.RawQuery = query.Encode()
+	return key.String()
+}
+
+// varyNames returns the request headers named by the response's Vary header.
+func varyNames(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// load reads a stored response and the time it was stored.
+func (t *Transport) load(path string, req *http.Request) (*http.Response, time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), req)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	stored, err := time.Parse(time.RFC3339Nano, resp.Header.Get(storedHeader))
+	if err != nil {
+		resp.Body.Close()
+		return nil, time.Time{}, err
+	}
+	resp.Header.Del(storedHeader)
+	for _, name := range varyNames(resp.Header) {
+		if resp.Header.Get(varyHeaderPrefix+name) != strings.Join(req.Header.Values(name), ", ") {
+			resp.Body.Close()
+			return nil, time.Time{}, fmt.Errorf("cached response varies on %s", name)
+		}
+		resp.Header.Del(varyHeaderPrefix + name)
+	}
+	return resp, stored, nil
+}
+
+func (t *Transport) fetch(req *http.Request, path string) (*http.Response, error) {
+	resp, err := t.base().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	return t.store(req, resp, path)
+}
+
+// store writes a successful response to req to the cache and returns an
+// equivalent response for the caller. Responses varying on everything are
+// not stored.
+func (t *Transport) store(req *http.Request, resp *http.Response, path string) (*http.Response, error) {
+	vary := varyNames(resp.Header)
+	if resp.StatusCode != http.StatusOK || slices.Contains(vary, "*") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry := *resp
+	entry.Header = resp.Header.Clone()
+	entry.Header.Set(storedHeader, time.Now().UTC().Format(time.RFC3339Nano))
+	for _, name := range vary {
+		entry.Header.Set(varyHeaderPrefix+name, strings.Join(req.Header.Values(name), ", "))
+	}
+	entry.Body = io.NopCloser(bytes.NewReader(body))
+	entry.ContentLength = int64(len(body))
+	entry.TransferEncoding = nil
+	entry.Header.Del("Content-Encoding") // The body is already decoded.
+	if dump, err := httputil.DumpResponse(&entry, true); err == nil {
+		if atomicfile.Write(path, dump) == nil {
+			t.evict()
+		}
+	}
+	return resp, nil
+}
+
+// touch marks an entry as recently used for eviction.
+func (t *Transport) touch(path string) {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
+
+// evict removes the least recently used entries until the cache fits
+// MaxBytes.
+func (t *Transport) evict() {
+	limit := t.MaxBytes
+	if limit <= 0 {
+		limit = DefaultMaxBytes
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entries, err := os.ReadDir(t.Dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var files []file
+	var total int64
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, file{filepath.Join(t.Dir, entry.Name()), info.Size(), info.ModTime()})
+		total += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= limit {
+			break
+		}
+		if os.Remove(f.path) == nil {
+			total -= f.size
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRevalidation(t *testing.T) {
+	const lastModified = "Mon, 02 Jan 2006 15:04:05 GMT"
+	tests := []struct {
+		name         string
+		etag         string
+		lastModified string
+		ttl          time.Duration
+		wantHits     int32 // Requests reaching the server for two GETs.
+		wantFull     int32 // Of which answered with a body.
+	}{
+		{name: "etag", etag: `"v1"`, wantHits: 2, wantFull: 1},
+		{name: "last modified", lastModified: lastModified, wantHits: 2, wantFull: 1},
+		{name: "no validators", wantHits: 2, wantFull: 2},
+		{name: "fresh within ttl", etag: `"v1"`, ttl: time.Hour, wantHits: 1, wantFull: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits, full atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if tt.etag != "" && r.Header.Get("If-None-Match") == tt.etag ||
+					tt.lastModified != "" && r.Header.Get("If-Modified-Since") == tt.lastModified {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				full.Add(1)
+				if tt.etag != "" {
+					w.Header().Set("ETag", tt.etag)
+				}
+				if tt.lastModified != "" {
+					w.Header().Set("Last-Modified", tt.lastModified)
+				}
+				io.WriteString(w, "captions")
+			}))
+			defer server.Close()
+
+			cache, err := New(t.TempDir(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache.TTL = tt.ttl
+			client := &http.Client{Transport: cache}
+			for i := range 2 {
+				resp, err := client.Get(server.URL + "/api/timedtext?lang=en")
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil || resp.StatusCode != http.StatusOK || string(body) != "captions" {
+					t.Fatalf("GET %d = %d %q, %v", i, resp.StatusCode, body, err)
+				}
+				if resp.Header.Get(storedHeader) != "" {
+					t.Errorf("GET %d leaked the %s header", i, storedHeader)
+				}
+			}
+			if hits.Load() != tt.wantHits || full.Load() != tt.wantFull {
+				t.Errorf("server saw %d requests, %d full, want %d and %d", hits.Load(), full.Load(), tt.wantHits, tt.wantFull)
+			}
+		})
+	}
+}
+
+func TestBypass(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		io.WriteString(w, "body")
+	}))
+	defer server.Close()
+
+	cache, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.TTL = time.Hour
+	client := &http.Client{Transport: cache}
+	for range 2 {
+		resp, err := client.Post(server.URL, "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		req.Header.Set("Range", "bytes=0-1")
+		if resp, err = client.Do(req); err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if hits.Load() != 4 {
+		t.Errorf("server saw %d requests, want 4", hits.Load())
+	}
+}
+
+func TestKeyHeadersAndVary(t *testing.T) {
+	tests := []struct {
+		name     string
+		vary     string
+		header   string   // Request header set to each of values in turn.
+		values   []string // Sent in this order, each with a long TTL.
+		shared   bool     // Whether every request gets the first response.
+		wantFull int32    // Requests the server answered with a body.
+	}{
+		{name: "language", header: "Accept-Language", values: []string{"en", "de", "en", "de"}, wantFull: 2},
+		{name: "user", header: "Authorization", values: []string{"Bearer a", "", "Bearer a", ""}, wantFull: 2},
+		{name: "vary", vary: "X-Region", header: "X-Region", values: []string{"US", "US", "DE", "DE"}, wantFull: 2},
+		{name: "vary on a changed value replaces the entry", vary: "X-Region", header: "X-Region", values: []string{"US", "DE", "US"}, wantFull: 3},
+		{name: "unlisted header is ignored", header: "X-Region", values: []string{"US", "DE"}, shared: true, wantFull: 1},
+		{name: "vary star is not stored", vary: "*", header: "X-Region", values: []string{"US", "US"}, wantFull: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var full atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				full.Add(1)
+				if tt.vary != "" {
+					w.Header().Set("Vary", tt.vary)
+				}
+				io.WriteString(w, r.Header.Get(tt.header))
+			}))
+			defer server.Close()
+
+			cache, err := New(t.TempDir(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache.TTL = time.Hour
+			client := &http.Client{Transport: cache}
+			for _, value := range tt.values {
+				req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+				if value != "" {
+					req.Header.Set(tt.header, value)
+				}
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				want := value
+				if tt.shared {
+					want = tt.values[0]
+				}
+				if string(body) != want {
+					t.Errorf("%s %q got the response for %q", tt.header, value, body)
+				}
+				for name := range resp.Header {
+					if strings.HasPrefix(name, "X-Httpcache-") {
+						t.Errorf("response leaked the %s header", name)
+					}
+				}
+			}
+			if full.Load() != tt.wantFull {
+				t.Errorf("server answered %d requests, want %d", full.Load(), tt.wantFull)
+			}
+		})
+	}
+}
+
+func TestVolatileParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		urls     []string
+		wantFull int32
+	}{
+		{
+			name:     "timedtext signature and expiry",
+			urls:     []string{"/api/timedtext?v=a&lang=en&expire=1&ei=x&sparams=ip&signature=s1", "/api/timedtext?v=a&lang=en&expire=2&ei=y&sparams=ip&signature=s2"},
+			wantFull: 1,
+		},
+		{
+			name:     "timedtext language",
+			urls:     []string{"/api/timedtext?v=a&lang=en&expire=1", "/api/timedtext?v=a&lang=de&expire=1"},
+			wantFull: 2,
+		},
+		{
+			name:     "other paths keep every parameter",
+			urls:     []string{"/watch?v=a&expire=1", "/watch?v=a&expire=2"},
+			wantFull: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var full atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				full.Add(1)
+				io.WriteString(w, r.URL.Query().Get("lang"))
+			}))
+			defer server.Close()
+
+			cache, err := New(t.TempDir(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache.TTL = time.Hour
+			client := &http.Client{Transport: cache}
+			for _, u := range tt.urls {
+				resp, err := client.Get(server.URL + u)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resp.Body.Close()
+			}
+			if full.Load() != tt.wantFull {
+				t.Errorf("server answered %d requests, want %d", full.Load(), tt.wantFull)
+			}
+		})
+	}
+}
+
+func TestCachePost(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		cachePost bool
+		bodies    []string
+		wantFull  int32
+	}{
+		{name: "same body", ttl: time.Hour, cachePost: true, bodies: []string{"a", "a"}, wantFull: 1},
+		{name: "different bodies", ttl: time.Hour, cachePost: true, bodies: []string{"a", "b", "a"}, wantFull: 2},
+		{name: "not opted in", ttl: time.Hour, bodies: []string{"a", "a"}, wantFull: 2},
+		{name: "no ttl", cachePost: true, bodies: []string{"a", "a"}, wantFull: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var full atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				full.Add(1)
+				io.Copy(w, r.Body)
+			}))
+			defer server.Close()
+
+			cache, err := New(t.TempDir(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cache.TTL = tt.ttl
+			if tt.cachePost {
+				cache.CachePost = func(*http.Request) bool { return true }
+			}
+			client := &http.Client{Transport: cache}
+			for _, body := range tt.bodies {
+				resp, err := client.Post(server.URL, "application/json", strings.NewReader(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if string(got) != body {
+					t.Errorf("POST %q got the response for %q", body, got)
+				}
+			}
+			if full.Load() != tt.wantFull {
+				t.Errorf("server answered %d requests, want %d", full.Load(), tt.wantFull)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package index provides a small persistent full-text index over transcripts
+// from many videos.
+package index
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"yt-transcript/yttranscript"
+)
+
+// Document is a single indexed transcript segment.
+type Document struct {
+	VideoID string
+	Start   float64
+	Text    string
+}
+
+// Result is a segment matching a query.
+type Result struct {
+	VideoID string
+	Start   float64
+	Snippet string
+	Score   int
+}
+
+// Link returns a link that opens the video at the matching segment.
+func (r Result) Link() string {
+	return yttranscript.DeepLink(r.VideoID, r.Start)
+}
+
+// Index is an inverted index mapping terms to transcript segments.
+// It is not safe for concurrent use.
+type Index struct {
+	Documents []Document
+	Postings  map[string][]int
+}
+
+// New creates an empty Index.
+func New() *Index {
+	return &Index{Postings: make(map[string][]int)}
+}
+
+// Open loads an index from path. A missing file yields an empty index.
+func Open(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index: %w", err)
+	}
+	defer f.Close()
+
+	ix := New()
+	if err := gob.NewDecoder(f).Decode(ix); err != nil {
+		return nil, fmt.Errorf("failed to decode index: %w", err)
+	}
+	return ix, nil
+}
+
+// Save writes the index to path, replacing any existing file atomically.
+func (ix *Index) Save(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".index-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(ix); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Add indexes every segment of the transcript. Segments previously indexed
+// for the same video are replaced.
+func (ix *Index) Add(transcript *yttranscript.Transcript) {
+	if ix.Contains(transcript.VideoID) {
+		ix.Remove(transcript.VideoID)
+	}
+	for _, text := range transcript.Texts {
+		ix.addDocument(Document{
+			VideoID: transcript.VideoID,
+			Start:   text.Start,
+			Text:    text.Content,
+		})
+	}
+}
+
+// Contains reports whether any segment of the video is indexed.
+func (ix *Index) Contains(videoID string) bool {
+	for _, doc := range ix.Documents {
+		if doc.VideoID == videoID {
+			return true
+		}
+	}
+	return false
+}
+
+// Transcripts rebuilds the indexed transcripts, one per video in the order
+// they were added, with the text and start time of every segment. Durations,
+// titles and other metadata are not indexed and are left empty.
+func (ix *Index) Transcripts() []*yttranscript.Transcript {
+	var transcripts []*yttranscript.Transcript
+	byVideo := make(map[string]*yttranscript.Transcript)
+	for _, doc := range ix.Documents {
+		transcript := byVideo[doc.VideoID]
+		if transcript == nil {
+			transcript = &yttranscript.Transcript{VideoID: doc.VideoID}
+			byVideo[doc.VideoID] = transcript
+			transcripts = append(transcripts, transcript)
+		}
+		transcript.Texts = append(transcript.Texts, yttranscript.Text{Start: doc.Start, Content: doc.Text})
+	}
+	return transcripts
+}
+
+// Remove drops every segment of the video from the index.
+func (ix *Index) Remove(videoID string) {
+	docs := ix.Documents
+	ix.Documents = nil
+	ix.Postings = make(map[string][]int)
+	for _, doc := range docs {
+		if doc.VideoID != videoID {
+			ix.addDocument(doc)
+		}
+	}
+}
+
+// Search returns segments containing every term of the query, best matches
+// first. A limit of zero or less returns all matches.
+func (ix *Index) Search(query string, limit int) []Result {
+	terms := Tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	// Intersect the postings of every term, then score each surviving
+	// segment by how often the query terms occur in it.
+	candidates := ix.Postings[terms[0]]
+	for _, term := range terms[1:] {
+		candidates = intersect(candidates, ix.Postings[term])
+	}
+
+	scores := make(map[int]int, len(candidates))
+	for _, id := range candidates {
+		for _, token := range Tokenize(ix.Documents[id].Text) {
+			if slices.Contains(terms, token) {
+				scores[id]++
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		doc := ix.Documents[id]
+		results = append(results, Result{
+			VideoID: doc.VideoID,
+			Start:   doc.Start,
+			Snippet: doc.Text,
+			Score:   score,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].VideoID != results[j].VideoID {
+			return results[i].VideoID < results[j].VideoID
+		}
+		return results[i].Start < results[j].Start
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// intersect returns the ids present in both sorted posting lists.
+func intersect(a, b []int) []int {
+	var out []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+func (ix *Index) addDocument(doc Document) {
+	id := len(ix.Documents)
+	ix.Documents = append(ix.Documents, doc)
+	for _, term := range Tokenize(doc.Text) {
+		postings := ix.Postings[term]
+		if n := len(postings); n > 0 && postings[n-1] == id {
+			continue
+		}
+		ix.Postings[term] = append(postings, id)
+	}
+}
+
+// Tokenize lowercases text and splits it into letter and digit runs.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// VideoCount returns the number of distinct videos in the index.
+func (ix *Index) VideoCount() int {
+	videos := make(map[string]bool)
+	for _, doc := range ix.Documents {
+		videos[doc.VideoID] = true
+	}
+	return len(videos)
+}
+
+// VideoFrequency returns the number of distinct videos whose transcript
+// contains term.
+func (ix *Index) VideoFrequency(term string) int {
+	videos := make(map[string]bool)
+	for _, id := range ix.Postings[term] {
+		videos[ix.Documents[id].VideoID] = true
+	}
+	return len(videos)
+}
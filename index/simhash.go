@@ -0,0 +1,110 @@
+package index
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// DefaultDuplicateDistance is the largest number of differing fingerprint
+// bits at which Alternates treats two transcripts as the same content. It
+// allows for about one caption line in twenty differing, while unrelated
+// transcripts differ in around 32 bits.
+const DefaultDuplicateDistance = 10
+
+// shingleSize is the number of consecutive words hashed together as one
+// fingerprint feature. Single words would make any two videos on the same
+// topic look alike.
+const shingleSize = 3
+
+// Fingerprint returns the 64-bit simhash of a video's indexed transcript.
+// Transcripts of the same content, such as re-uploads with slightly
+// different captions, have fingerprints differing in only a few bits. It
+// reports false if the video is not indexed.
+func (ix *Index) Fingerprint(videoID string) (uint64, bool) {
+	fingerprint, ok := ix.fingerprints()[videoID]
+	return fingerprint, ok
+}
+
+// Duplicates returns the other indexed videos whose fingerprint differs from
+// videoID's in at most maxDistance bits, closest first.
+func (ix *Index) Duplicates(videoID string, maxDistance int) []string {
+	fingerprints := ix.fingerprints()
+	target, ok := fingerprints[videoID]
+	if !ok {
+		return nil
+	}
+
+	distances := make(map[string]int)
+	var duplicates []string
+	for other, fingerprint := range fingerprints {
+		if other == videoID {
+			continue
+		}
+		if d := bits.OnesCount64(target ^ fingerprint); d <= maxDistance {
+			distances[other] = d
+			duplicates = append(duplicates, other)
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		if distances[duplicates[i]] != distances[duplicates[j]] {
+			return distances[duplicates[i]] < distances[duplicates[j]]
+		}
+		return duplicates[i] < duplicates[j]
+	})
+	return duplicates
+}
+
+// Alternates implements yttranscript.AlternateSources, offering the indexed
+// duplicates of a video within DefaultDuplicateDistance.
+func (ix *Index) Alternates(videoID string) []string {
+	return ix.Duplicates(videoID, DefaultDuplicateDistance)
+}
+
+// fingerprints computes the simhash of every indexed video.
+func (ix *Index) fingerprints() map[string]uint64 {
+	byVideo := make(map[string][]Document)
+	for _, doc := range ix.Documents {
+		byVideo[doc.VideoID] = append(byVideo[doc.VideoID], doc)
+	}
+
+	fingerprints := make(map[string]uint64, len(byVideo))
+	for videoID, docs := range byVideo {
+		sort.SliceStable(docs, func(i, j int) bool { return docs[i].Start < docs[j].Start })
+		var words []string
+		for _, doc := range docs {
+			words = append(words, Tokenize(doc.Text)...)
+		}
+		fingerprints[videoID] = simhash(words)
+	}
+	return fingerprints
+}
+
+// simhash combines the hashes of every run of shingleSize words, so that
+// the result changes little when few words change.
+func simhash(words []string) uint64 {
+	var weights [64]int
+	// Texts shorter than a shingle are hashed whole.
+	shingles := max(len(words)-shingleSize+1, min(len(words), 1))
+	for i := range shingles {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:min(i+shingleSize, len(words))], " ")))
+		sum := h.Sum64()
+		for bit := range weights {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"yt-transcript/index"
+	"yt-transcript/yttranscript"
+)
+
+// runIndex adds transcripts to an index file or searches one.
+func runIndex(args []string) {
+	if len(args) < 3 {
+		log.Fatal(usage)
+	}
+	action := args[0]
+	fs := flag.NewFlagSet("index "+action, flag.ExitOnError)
+	nice := registerSingleNiceFlag(fs)
+	fs.Parse(args[1:])
+	if fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+	path := fs.Arg(0)
+	applyNice(*nice)
+
+	ix, err := index.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open index: %v", err)
+	}
+
+	switch action {
+	case "add":
+		videoID := videoIDArg(fs.Arg(1))
+		languageCode := fs.Arg(2)
+
+		client, err := yttranscript.New()
+		if err != nil {
+			log.Fatalf("Failed to create client: %v", err)
+		}
+		transcript, err := client.GetTranscript(videoID, languageCode)
+		if err != nil {
+			log.Fatalf("Failed to get transcript: %v", err)
+		}
+
+		ix.Add(transcript)
+		if err := ix.Save(path); err != nil {
+			log.Fatalf("Failed to save index: %v", err)
+		}
+		fmt.Printf("Indexed %d lines from %s.\n", len(transcript.Texts), videoID)
+	case "search":
+		query := strings.Join(fs.Args()[1:], " ")
+		results := ix.Search(query, 0)
+		if len(results) == 0 {
+			fmt.Printf("No matches found for %q.\n", query)
+			return
+		}
+		for _, result := range results {
+			fmt.Printf("%s [%s] %s\n", result.VideoID, yttranscript.FormatTimestamp(result.Start), result.Snippet)
+		}
+	default:
+		log.Fatal(usage)
+	}
+}
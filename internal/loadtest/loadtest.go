@@ -0,0 +1,135 @@
+// Package loadtest measures the throughput of the fetch, parse and format
+// pipeline against the fake YouTube server, so that performance regressions
+// show up before a release.
+package loadtest
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+// Config controls a load test run.
+type Config struct {
+	Videos      int    // Number of transcripts to fetch.
+	Concurrency int    // Number of concurrent workers.
+	Format      string // Export format each transcript is rendered in.
+}
+
+// Result summarises a load test run.
+type Result struct {
+	Videos        int
+	Failures      int
+	Elapsed       time.Duration
+	Allocs        uint64 // Heap allocations during the run.
+	AllocBytes    uint64 // Bytes allocated during the run.
+	PeakHeapInUse uint64 // Highest heap in use sampled during the run.
+}
+
+// VideosPerSecond returns the throughput of the run.
+func (r Result) VideosPerSecond() float64 {
+	return float64(r.Videos) / r.Elapsed.Seconds()
+}
+
+// String formats the result as a short report.
+func (r Result) String() string {
+	return fmt.Sprintf("%d videos (%d failed) in %s: %.1f videos/sec, %d allocs/video, %d bytes/video, peak heap %d KiB",
+		r.Videos, r.Failures, r.Elapsed.Round(time.Millisecond), r.VideosPerSecond(),
+		r.Allocs/uint64(max(r.Videos, 1)), r.AllocBytes/uint64(max(r.Videos, 1)), r.PeakHeapInUse/1024)
+}
+
+// Run fetches cfg.Videos transcripts from a fake server with cfg.Concurrency
+// workers, rendering each one, and reports throughput and memory use.
+func Run(cfg Config) (Result, error) {
+	write, ok := export.Formats[cfg.Format]
+	if !ok {
+		return Result{}, fmt.Errorf("unknown format %q", cfg.Format)
+	}
+	if cfg.Videos < 1 || cfg.Concurrency < 1 {
+		return Result{}, fmt.Errorf("videos and concurrency must be positive")
+	}
+
+	server := yttranscripttest.NewServer()
+	defer server.Close()
+	client, err := server.NewClient()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	var failures atomic.Int64
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+
+	stopSampling := make(chan struct{})
+	peak := make(chan uint64)
+	go sampleHeap(stopSampling, peak)
+
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for range cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				if err := fetchAndRender(client, write); err != nil {
+					failures.Add(1)
+				}
+			}
+		}()
+	}
+	for range cfg.Videos {
+		jobs <- struct{}{}
+	}
+	close(jobs)
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+	close(stopSampling)
+
+	return Result{
+		Videos:        cfg.Videos,
+		Failures:      int(failures.Load()),
+		Elapsed:       elapsed,
+		Allocs:        after.Mallocs - before.Mallocs,
+		AllocBytes:    after.TotalAlloc - before.TotalAlloc,
+		PeakHeapInUse: <-peak,
+	}, nil
+}
+
+func fetchAndRender(client *yttranscript.Client, write export.WriterFunc) error {
+	transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en")
+	if err != nil {
+		return err
+	}
+	return write(io.Discard, transcript)
+}
+
+// sampleHeap polls heap usage until stop is closed, then sends the peak.
+func sampleHeap(stop <-chan struct{}, peak chan<- uint64) {
+	var highest uint64
+	var stats runtime.MemStats
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		runtime.ReadMemStats(&stats)
+		highest = max(highest, stats.HeapInuse)
+		select {
+		case <-ticker.C:
+		case <-stop:
+			peak <- highest
+			return
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// exitInterrupted is the exit status of a run stopped by SIGINT or SIGTERM,
+// the one shells report for SIGINT.
+const exitInterrupted = 130
+
+// interruptGrace is how long in-flight videos may take to finish after a
+// stop signal before the process exits anyway.
+const interruptGrace = 10 * time.Second
+
+// interrupt is canceled on the first SIGINT or SIGTERM once a batch command
+// has called watchInterrupts. Batch commands stop starting new videos when it
+// is done, write out what they have and call exitIfInterrupted. Other
+// commands leave it alone and keep the default signal handling.
+var interrupt = context.Background()
+
+// watchInterrupts installs the signal handler. After the first signal the
+// process exits with exitInterrupted once the command has wrapped up, after
+// interruptGrace, or on a second signal, whichever comes first.
+func watchInterrupts() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Printf("Received %v; finishing in-flight videos, send it again to stop immediately", sig)
+		cancel()
+		select {
+		case <-signals:
+		case <-time.After(interruptGrace):
+			log.Printf("Warning: in-flight videos did not finish within %v", interruptGrace)
+		}
+		os.Exit(exitInterrupted)
+	}()
+	return ctx
+}
+
+// exitIfInterrupted exits with exitInterrupted if a stop signal was received.
+func exitIfInterrupted() {
+	if interrupt.Err() != nil {
+		log.Printf("Interrupted; run the command again to resume")
+		os.Exit(exitInterrupted)
+	}
+}
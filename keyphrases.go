@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"yt-transcript/analyze"
+	"yt-transcript/index"
+	"yt-transcript/yttranscript"
+)
+
+// runKeyphrases extracts the key phrases of one or more videos and prints
+// them with the times they are spoken.
+func runKeyphrases(args []string) {
+	fs := flag.NewFlagSet("keyphrases", flag.ExitOnError)
+	method := fs.String("method", "rake", "scoring method: rake or tfidf")
+	indexPath := fs.String("index", "", "index file of related videos to weight terms against (tfidf)")
+	limit := fs.Int("n", 10, "number of phrases to list")
+	maxWords := fs.Int("words", 3, "longest phrase, in words")
+	languageCode := fs.String("lang", "", "language code of the transcripts")
+	asJSON := fs.Bool("json", false, "print phrases as JSON")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+
+	opts := analyze.KeyphraseOptions{Limit: *limit, MaxWords: *maxWords}
+	switch *method {
+	case "rake":
+		opts.Method = analyze.RAKE
+	case "tfidf":
+		opts.Method = analyze.TFIDF
+	default:
+		log.Fatalf("Unknown method %q (want rake or tfidf)", *method)
+	}
+	if *indexPath != "" {
+		ix, err := index.Open(*indexPath)
+		if err != nil {
+			log.Fatalf("Failed to open index: %v", err)
+		}
+		opts.Corpus = ix
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcripts := make([]*yttranscript.Transcript, fs.NArg())
+	for i, arg := range fs.Args() {
+		videoID := videoIDArg(arg)
+		if transcripts[i], err = client.GetTranscript(videoID, *languageCode); err != nil {
+			log.Fatalf("Failed to get transcript of %s: %v", videoID, err)
+		}
+	}
+
+	keyphrases := analyze.Keyphrases(transcripts, opts)
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(keyphrases); err != nil {
+			log.Fatalf("Failed to write keyphrases: %v", err)
+		}
+		return
+	}
+
+	for _, keyphrase := range keyphrases {
+		times := make([]string, len(keyphrase.Occurrences))
+		for i, occurrence := range keyphrase.Occurrences {
+			times[i] = yttranscript.FormatTimestamp(occurrence.Start)
+			if len(transcripts) > 1 {
+				times[i] = occurrence.VideoID + "@" + times[i]
+			}
+		}
+		fmt.Printf("%-30s %.4f (%d) %s\n", keyphrase.Phrase, keyphrase.Score, keyphrase.Count, strings.Join(times, ", "))
+	}
+}
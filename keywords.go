@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"yt-transcript/analyze"
+	"yt-transcript/index"
+	"yt-transcript/yttranscript"
+)
+
+// runKeywords suggests keywords and hashtags for a video, weighting terms
+// against an index of the channel's other videos when one is given.
+func runKeywords(args []string) {
+	fs := flag.NewFlagSet("keywords", flag.ExitOnError)
+	indexPath := fs.String("index", "", "index file of related videos to weight terms against")
+	limit := fs.Int("n", 10, "number of keywords to suggest")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+	videoID, languageCode := videoIDArg(fs.Arg(0)), fs.Arg(1)
+
+	var corpus analyze.Corpus
+	if *indexPath != "" {
+		ix, err := index.Open(*indexPath)
+		if err != nil {
+			log.Fatalf("Failed to open index: %v", err)
+		}
+		corpus = ix
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	keywords := analyze.Keywords(transcript, corpus, *limit)
+	hashtags := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		fmt.Printf("%-20s %.4f (%d)\n", keyword.Term, keyword.Score, keyword.Count)
+		hashtags[i] = keyword.Hashtag()
+	}
+	fmt.Printf("\n%s\n", strings.Join(hashtags, " "))
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"yt-transcript/export"
+	"yt-transcript/internal/loadtest"
+)
+
+// runLoadTest measures pipeline throughput against the fake YouTube server.
+func runLoadTest(args []string) {
+	flags := flag.NewFlagSet("loadtest", flag.ExitOnError)
+	videos := flags.Int("videos", 1000, "number of transcripts to fetch")
+	concurrency := flags.Int("concurrency", 8, "number of concurrent workers")
+	format := flags.String("format", "whisper", "export format: "+strings.Join(export.FormatNames(), ", "))
+	flags.Parse(args)
+
+	result, err := loadtest.Run(loadtest.Config{
+		Videos:      *videos,
+		Concurrency: *concurrency,
+		Format:      *format,
+	})
+	if err != nil {
+		log.Fatalf("Load test failed: %v", err)
+	}
+	fmt.Println(result)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"yt-transcript/oauth"
+)
+
+// runLogin signs in to a Google account with the device flow and stores the
+// tokens in a credentials file for -credentials.
+func runLogin(args []string) {
+	fs := flag.NewFlagSet("login", flag.ExitOnError)
+	path := fs.String("credentials", defaultCredentialsPath(), "file to store the OAuth tokens in")
+	fs.Parse(args)
+
+	cfg := oauth.ConfigFromEnv()
+	if cfg.ClientID == "" {
+		log.Fatalf("Set %s and %s to the OAuth client to sign in with", oauth.EnvClientID, oauth.EnvClientSecret)
+	}
+	ctx := context.Background()
+	code, err := cfg.StartDeviceFlow(ctx)
+	if err != nil {
+		log.Fatalf("Failed to start sign-in: %v", err)
+	}
+	fmt.Printf("Go to %s and enter the code %s\n", code.VerificationURL, code.UserCode)
+
+	token, err := cfg.PollToken(ctx, code)
+	if err != nil {
+		log.Fatalf("Failed to sign in: %v", err)
+	}
+	if err := oauth.WriteToken(*path, token); err != nil {
+		log.Fatalf("Failed to save credentials: %v", err)
+	}
+	fmt.Printf("Signed in; credentials saved to %s\n", *path)
+}
+
+// defaultCredentialsPath is the credentials file in the user's configuration
+// directory, or in the working directory if there is none.
+func defaultCredentialsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "credentials.json"
+	}
+	return filepath.Join(dir, "yt-transcript", "credentials.json")
+}
+
+// savedCredentialsPath returns defaultCredentialsPath if login has written
+// credentials there, and an empty path otherwise.
+func savedCredentialsPath() string {
+	path := defaultCredentialsPath()
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
@@ -1,28 +1,262 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"math"
 	"os"
+	"strings"
+	"time"
 
+	"yt-transcript/export"
+	"yt-transcript/httpcache"
+	"yt-transcript/index"
+	"yt-transcript/oauth"
+	"yt-transcript/replay"
 	"yt-transcript/yttranscript"
 )
 
+const usage = `Usage: go run . [-v] [-cache dir [-cache-ttl d]] [-record dir | -replay dir] [-credentials file] [-country code] [-substitute policy] [-alternates file | -alternates-index file] [-from time] [-to time] [-scale factor] [-shift d] [-format name [-computed] [-annotate] [-sections] | -interleave language_code | -stats] [-nice] <video_id> [language_code]
+       go run . search [-links] <video_id> <query> [language_code]
+       go run . compare <video_id> <script_file> [language_code]
+       go run . verify [-min score] <video_id> <quote> [language_code]
+       go run . meta [-json] <video_id>
+       go run . resolve [-n count] <@channel> <title>
+       go run . availability [-json] [-ids file] [-concurrency n] [-nice] [video_id...]
+       go run . readability [-json] <video_id> [language_code]
+       go run . keywords [-index index_file] [-n count] <video_id> [language_code]
+       go run . keyphrases [-method rake|tfidf] [-index index_file] [-n count] [-words n] [-lang code] [-json] <video_id>...
+       go run . concordance [-index index_file] [-context n] [-case] [-lang code] [-json] <query> [video_id...]
+       go run . highlights [-n count] [-window d] <video_id> [language_code]
+       go run . summarize <video_id> [language_code]
+       go run . embed [-window d] [-overlap d] <video_id> [language_code]
+       go run . anki [-translate] <video_id> <front_language_code> <back_language_code>
+       go run . merge [-lang code] [-gap d] <video_id> <reupload_video_id>...
+       go run . feed [-out dir | -bucket name | -sink url] [-state file] [-format name] [-substitute policy] [-give-up d] [-nice] [-dry-run] <channel_id> [language_code]
+       go run . watch -channel id [-interval d] [feed flags] [language_code]
+       go run . upload [-format name [-computed] [-annotate] [-sections]] [-nice] [-endpoint url] [-region r] [-key template] <bucket> <video_id> [language_code]
+       go run . login [-credentials file]
+       go run . loadtest [-videos n] [-concurrency n] [-format name]
+       go run . index add [-nice] <index_file> <video_id> [language_code]
+       go run . index search <index_file> <query>`
+
 func main() {
 	if len(os.Args) < 2 {
-		log.Fatalf("Usage: go run main.go <video_id> [language_code]")
+		log.Fatal(usage)
+	}
+
+	switch os.Args[1] {
+	case "search":
+		runSearch(os.Args[2:])
+		return
+	case "index":
+		runIndex(os.Args[2:])
+		return
+	case "meta":
+		runMeta(os.Args[2:])
+		return
+	case "resolve":
+		runResolve(os.Args[2:])
+		return
+	case "availability":
+		runAvailability(os.Args[2:])
+		return
+	case "readability":
+		runReadability(os.Args[2:])
+		return
+	case "keywords":
+		runKeywords(os.Args[2:])
+		return
+	case "anki":
+		runAnki(os.Args[2:])
+		return
+	case "keyphrases":
+		runKeyphrases(os.Args[2:])
+		return
+	case "concordance":
+		runConcordance(os.Args[2:])
+		return
+	case "highlights":
+		runHighlights(os.Args[2:])
+		return
+	case "summarize":
+		runSummarize(os.Args[2:])
+		return
+	case "embed":
+		runEmbed(os.Args[2:])
+		return
+	case "merge":
+		runMerge(os.Args[2:])
+		return
+	case "compare":
+		runCompare(os.Args[2:])
+		return
+	case "verify":
+		runVerify(os.Args[2:])
+		return
+	case "feed":
+		runFeed(os.Args[2:])
+		return
+	case "watch":
+		runWatch(os.Args[2:])
+		return
+	case "upload":
+		runUpload(os.Args[2:])
+		return
+	case "login":
+		runLogin(os.Args[2:])
+		return
+	case "loadtest":
+		runLoadTest(os.Args[2:])
+		return
+	}
+
+	format := flag.String("format", "", "output format: "+strings.Join(export.FormatNames(), ", "))
+	interleave := flag.String("interleave", "", "print each line followed by its machine translation into this language")
+	country := flag.String("country", "", "two-letter country code to request captions as seen from")
+	substitute := flag.String("substitute", "fail", "what to deliver when the language is missing: fail, default, translate or any-manual")
+	alternatesPath := flag.String("alternates", "", "file mapping videos to duplicates to use when they are unavailable")
+	alternatesIndex := flag.String("alternates-index", "", "index file whose near-duplicate transcripts are used when a video is unavailable")
+	cacheDir := flag.String("cache", "", "directory to cache watch pages and caption tracks in")
+	cacheTTL := flag.Duration("cache-ttl", time.Hour, "reuse cached responses younger than this without asking YouTube; watch and player pages are only reused within it")
+	recordDir := flag.String("record", "", "directory to record every upstream response in")
+	replayDir := flag.String("replay", "", "directory of a recording to answer requests from instead of YouTube")
+	credentialsPath := flag.String("credentials", savedCredentialsPath(), "credentials file written by login, to fetch videos as that account; empty to sign out")
+	verbose := flag.Bool("v", false, "log each request and fallback decision to stderr")
+	computed := flag.Bool("computed", false, "add index, end time and character/word counts to csv, tsv and json output")
+	annotate := flag.Bool("annotate", false, "mark automatic captions and machine translations in json, markdown and ass output")
+	sections := flag.Bool("sections", false, "add a heading at every topic change to markdown output of videos without chapters")
+	from := flag.String("from", "", "only print captions from this time, such as 1:23:00")
+	to := flag.String("to", "", "only print captions until this time, such as 1:30:00")
+	scale := flag.Float64("scale", 1, "multiply all times by this factor, such as 1.0427 (25/23.976) for a copy of the video at another frame rate")
+	shift := flag.Duration("shift", 0, "move all times by this offset, after -scale; negative to sync with a trimmed copy of the video")
+	showStats := flag.Bool("stats", false, "print word count, coverage, speaking rate and longest gaps instead of the transcript")
+	nice := registerSingleNiceFlag(flag.CommandLine)
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		log.Fatal(usage)
+	}
+	videoID := videoIDArg(args[0])
+	applyNice(*nice)
+
+	var fromTime, toTime time.Duration
+	var err error
+	if *from != "" {
+		if fromTime, err = yttranscript.ParseTimestamp(*from); err != nil {
+			log.Fatalf("Invalid -from: %v", err)
+		}
+	}
+	if *to != "" {
+		if toTime, err = yttranscript.ParseTimestamp(*to); err != nil {
+			log.Fatalf("Invalid -to: %v", err)
+		}
+		if toTime <= fromTime {
+			log.Fatal("-to must be later than -from")
+		}
+	}
+	if !(*scale > 0) || math.IsInf(*scale, 0) {
+		log.Fatalf("Invalid -scale %v: must be a finite factor greater than zero", *scale)
+	}
+
+	var write export.WriterFunc
+	if *format != "" {
+		var ok bool
+		if write, ok = export.Writer(*format, export.Options{ComputedFields: *computed, Annotate: *annotate, Sections: *sections}); !ok {
+			log.Fatalf("Unknown format %q, expected one of: %s", *format, strings.Join(export.FormatNames(), ", "))
+		}
+	}
+
+	var clientOpts []yttranscript.Option
+	if *verbose {
+		logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		clientOpts = append(clientOpts, yttranscript.WithLogger(logger))
+	}
+
+	if *cacheDir != "" {
+		cache, err := httpcache.New(*cacheDir, nil)
+		if err != nil {
+			log.Fatalf("Failed to open cache: %v", err)
+		}
+		cache.TTL = *cacheTTL
+		clientOpts = append(clientOpts, yttranscript.WithCache(cache))
+	}
+
+	switch {
+	case *recordDir != "" && *replayDir != "":
+		log.Fatal("Only one of -record and -replay can be used")
+	case *recordDir != "":
+		recorder, err := replay.NewRecorder(*recordDir, nil)
+		if err != nil {
+			log.Fatalf("Failed to open recording: %v", err)
+		}
+		clientOpts = append(clientOpts, yttranscript.WithRecorder(recorder))
+	case *replayDir != "":
+		replayer, err := replay.NewReplayer(*replayDir)
+		if err != nil {
+			log.Fatalf("Failed to open recording: %v", err)
+		}
+		clientOpts = append(clientOpts, yttranscript.WithTransport(replayer))
+	}
+
+	if *credentialsPath != "" {
+		tokens, err := oauth.NewFileTokenSource(oauth.ConfigFromEnv(), *credentialsPath)
+		if err != nil {
+			log.Fatalf("Failed to load credentials: %v", err)
+		}
+		clientOpts = append(clientOpts, yttranscript.WithOAuthToken(tokens))
 	}
-	videoID := os.Args[1]
 
-	client, err := yttranscript.New()
+	client, err := yttranscript.New(clientOpts...)
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
 
-	if len(os.Args) == 2 {
+	var callOpts []yttranscript.CallOption
+	if *country != "" {
+		callOpts = append(callOpts, yttranscript.WithCountry(*country))
+	}
+	policy, err := yttranscript.ParseLanguagePolicy(*substitute)
+	if err != nil {
+		log.Fatalf("Invalid -substitute: %v", err)
+	}
+	callOpts = append(callOpts, yttranscript.WithLanguagePolicy(policy))
+	switch {
+	case *alternatesPath != "" && *alternatesIndex != "":
+		log.Fatal("Only one of -alternates and -alternates-index can be used")
+	case *alternatesPath != "":
+		alternates, err := readAlternateMap(*alternatesPath)
+		if err != nil {
+			log.Fatalf("Failed to read alternates: %v", err)
+		}
+		callOpts = append(callOpts, yttranscript.WithAlternateSources(alternates))
+	case *alternatesIndex != "":
+		ix, err := index.Open(*alternatesIndex)
+		if err != nil {
+			log.Fatalf("Failed to open index: %v", err)
+		}
+		callOpts = append(callOpts, yttranscript.WithAlternateSources(ix))
+	}
+
+	if *format != "" && *interleave != "" {
+		log.Fatal("-format and -interleave cannot be combined")
+	}
+	if *showStats && (*format != "" || *interleave != "") {
+		log.Fatal("-stats cannot be combined with -format or -interleave")
+	}
+
+	if len(args) == 1 && *format == "" && *interleave == "" && !*showStats {
 		// If no language code is provided, list available transcripts.
 		fmt.Println("Listing available transcripts...")
-		tracks, err := client.ListTranscripts(videoID)
+		tracks, err := client.ListTranscripts(videoID, callOpts...)
 		if err != nil {
 			log.Fatalf("Failed to list transcripts: %v", err)
 		}
@@ -37,14 +271,80 @@ func main() {
 		return
 	}
 
-	languageCode := os.Args[2]
-	transcript, err := client.GetTranscript(videoID, languageCode)
-	if err != nil {
-		log.Fatalf("Failed to get transcript: %v", err)
+	languageCode := ""
+	if len(args) > 1 {
+		languageCode = args[1]
 	}
 
-	fmt.Printf("\nTranscript (%s):\n", languageCode)
-	for _, text := range transcript.Texts {
-		fmt.Println(text.Content)
+	if *interleave != "" {
+		pair, err := client.GetTranscriptPair(videoID, languageCode, *interleave, callOpts...)
+		if err != nil {
+			log.Fatalf("Failed to get transcript pair: %v", err)
+		}
+		if err := export.WriteInterleaved(os.Stdout, pair); err != nil {
+			log.Fatalf("Failed to write transcript: %v", err)
+		}
+		return
+	}
+
+	var transcripts []*yttranscript.Transcript
+	if strings.Contains(languageCode, ",") {
+		// Several languages: print whichever succeeded and report the rest.
+		transcripts, err = client.GetTranscripts(videoID, strings.Split(languageCode, ","), callOpts...)
+		var partial *yttranscript.PartialError
+		if errors.As(err, &partial) && len(transcripts) > 0 {
+			for _, failure := range partial.Failures {
+				log.Printf("Warning: failed to get transcript (%s): %v", failure.LanguageCode, failure.Err)
+			}
+		} else if err != nil {
+			log.Fatalf("Failed to get transcripts: %v", err)
+		}
+	} else {
+		transcript, err := client.GetTranscript(videoID, languageCode, callOpts...)
+		if err != nil {
+			log.Fatalf("Failed to get transcript: %v", err)
+		}
+		transcripts = append(transcripts, transcript)
+	}
+
+	for i, transcript := range transcripts {
+		if *from != "" || *to != "" {
+			transcript = transcript.Slice(fromTime, toTime)
+		}
+		if *scale != 1 {
+			transcript = transcript.Scale(*scale)
+		}
+		if *shift != 0 {
+			transcript = transcript.Shift(*shift)
+		}
+		transcripts[i] = transcript
+		for _, warning := range transcript.Warnings {
+			log.Printf("Warning (%s): %s", transcript.LanguageCode, warning)
+		}
+		if *showStats {
+			printStats(transcript)
+			continue
+		}
+		if write != nil {
+			if err := write(os.Stdout, transcript); err != nil {
+				log.Fatalf("Failed to write transcript: %v", err)
+			}
+			continue
+		}
+
+		fmt.Printf("\nTranscript (%s):\n", transcript.LanguageCode)
+		for _, text := range transcript.Texts {
+			fmt.Println(text.Content)
+		}
+	}
+}
+
+// videoIDArg returns the video ID in a command-line argument, which may be a
+// bare ID or any YouTube video URL.
+func videoIDArg(arg string) string {
+	videoID, err := yttranscript.ParseVideoID(arg)
+	if err != nil {
+		log.Fatalf("Invalid video: %v", err)
 	}
+	return videoID
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+// runMerge fills the caption gaps of one video's transcript with the aligned
+// segments of its re-uploads and prints the result, marking borrowed lines
+// with the video they came from.
+func runMerge(args []string) {
+	fs := flag.NewFlagSet("merge", flag.ExitOnError)
+	languageCode := fs.String("lang", "", "language code of the transcripts to merge")
+	minGap := fs.Duration("gap", 5*time.Second, "shortest stretch without captions to fill")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	transcripts := make([]*yttranscript.Transcript, fs.NArg())
+	for i, arg := range fs.Args() {
+		videoID := videoIDArg(arg)
+		if transcripts[i], err = client.GetTranscript(videoID, *languageCode); err != nil {
+			log.Fatalf("Failed to get transcript of %s: %v", videoID, err)
+		}
+	}
+
+	merged := transcripts[0].FillGaps(*minGap, transcripts[1:]...)
+	for _, warning := range merged.Warnings {
+		log.Printf("Warning: %s", warning)
+	}
+	for _, text := range merged.Texts {
+		if text.Source != merged.VideoID {
+			fmt.Printf("[%s] %s (from %s)\n", yttranscript.FormatTimestamp(text.Start), text.Content, text.Source)
+			continue
+		}
+		fmt.Printf("[%s] %s\n", yttranscript.FormatTimestamp(text.Start), text.Content)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"yt-transcript/yttranscript"
+)
+
+// runMeta prints a video's metadata and caption availability.
+func runMeta(args []string) {
+	flags := flag.NewFlagSet("meta", flag.ExitOnError)
+	asJSON := flags.Bool("json", false, "print metadata as JSON")
+	flags.Parse(args)
+	if flags.NArg() < 1 {
+		log.Fatal(usage)
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	metadata, err := client.GetMetadata(videoIDArg(flags.Arg(0)))
+	if err != nil {
+		log.Fatalf("Failed to get metadata: %v", err)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(metadata); err != nil {
+			log.Fatalf("Failed to write metadata: %v", err)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Title:\t%s\n", metadata.Title)
+	fmt.Fprintf(w, "Channel:\t%s (%s)\n", metadata.Channel, metadata.ChannelID)
+	fmt.Fprintf(w, "Duration:\t%s\n", yttranscript.FormatTimestamp(metadata.Duration().Seconds()))
+	fmt.Fprintf(w, "Published:\t%s\n", metadata.PublishDate)
+	fmt.Fprintf(w, "Captions:\t%d tracks (%d manual, %d auto-generated): %s\n",
+		metadata.Captions.Total, metadata.Captions.Manual, metadata.Captions.Automatic,
+		strings.Join(metadata.Captions.Languages, ", "))
+	w.Flush()
+}
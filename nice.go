@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+)
+
+// registerNiceFlag adds the -nice flag shared by the batch commands.
+func registerNiceFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("nice", false, "run at low priority, one video at a time, pausing between videos so other services are not starved")
+}
+
+// registerSingleNiceFlag adds the -nice flag of the commands that handle a
+// single video, where nice mode only lowers the priority.
+func registerSingleNiceFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("nice", false, "run at low priority so other services are not starved")
+}
+
+// applyNice lowers the priority of the process when nice mode is on. Export
+// and index writes are done at that priority too, and so yield the CPU and,
+// where the I/O scheduler follows the CPU priority, the disk.
+func applyNice(nice bool) {
+	if !nice {
+		return
+	}
+	if err := lowerPriority(); err != nil {
+		log.Printf("Warning: failed to lower process priority: %v", err)
+	}
+}
+
+// newPacer returns the pacer for a batch command, lowering the priority of
+// the process when nice mode is on.
+func newPacer(nice bool) *pacer {
+	applyNice(nice)
+	return &pacer{enabled: nice}
+}
+
+// pacer spaces out the videos of a batch in nice mode. It is not safe for
+// concurrent use; nice mode processes one video at a time.
+type pacer struct {
+	enabled  bool
+	started  time.Time
+	previous time.Duration // How long the previous video took.
+}
+
+// wait is called before each video. In nice mode it pauses for as long as
+// the previous video took, so the batch uses at most half of the wall clock
+// time however fast the network and disk are. It reports false if a stop
+// signal cut the pause short.
+func (p *pacer) wait() bool {
+	if !p.enabled {
+		return true
+	}
+	if p.previous > 0 {
+		select {
+		case <-time.After(p.previous):
+		case <-interrupt.Done():
+			return false
+		}
+	}
+	p.started = time.Now()
+	return true
+}
+
+// done is called after each video to record how long it took.
+func (p *pacer) done() {
+	if p.enabled && !p.started.IsZero() {
+		p.previous = time.Since(p.started)
+	}
+}
+
+// reset forgets the previous video, so that the first video of a new pass,
+// such as the next poll of a watch, starts without a pause.
+func (p *pacer) reset() {
+	p.started, p.previous = time.Time{}, 0
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+// lowerPriority is a no-op where the scheduling priority cannot be set
+// portably; nice mode then only paces the work.
+func lowerPriority() error {
+	return nil
+}
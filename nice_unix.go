@@ -0,0 +1,12 @@
+//go:build unix
+
+package main
+
+import "syscall"
+
+// niceness is the scheduling priority nice mode runs at, as with nice(1).
+const niceness = 10
+
+func lowerPriority() error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, 0, niceness)
+}
@@ -0,0 +1,308 @@
+// Package oauth signs in to a Google account with the OAuth 2.0 device flow,
+// which needs no redirect URL and so works from a terminal, and keeps the
+// resulting tokens fresh in a credentials file.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Google's OAuth endpoints.
+const (
+	DeviceCodeURL = "https://oauth2.googleapis.com/device/code"
+	TokenURL      = "https://oauth2.googleapis.com/token"
+)
+
+// ScopeYouTube grants access to the account's YouTube data, including
+// members-only and private videos it can watch.
+const ScopeYouTube = "https://www.googleapis.com/auth/youtube"
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvClientID     = "YOUTUBE_CLIENT_ID"
+	EnvClientSecret = "YOUTUBE_CLIENT_SECRET"
+)
+
+// expiryMargin is how long before its expiry an access token is refreshed,
+// so that it does not expire while a request is in flight.
+const expiryMargin = time.Minute
+
+// ErrAccessDenied is returned by PollToken when the user declined the
+// sign-in.
+var ErrAccessDenied = errors.New("sign-in was declined")
+
+// ErrCodeExpired is returned by PollToken when the user did not sign in
+// before the device code expired.
+var ErrCodeExpired = errors.New("device code expired before sign-in")
+
+// Config identifies the OAuth client, which must be a Google Cloud OAuth
+// client of the "TVs and Limited Input devices" type.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string     // Defaults to ScopeYouTube.
+	HTTPClient   *http.Client // Defaults to http.DefaultClient.
+}
+
+// ConfigFromEnv reads the client credentials from YOUTUBE_CLIENT_ID and
+// YOUTUBE_CLIENT_SECRET.
+func ConfigFromEnv() Config {
+	return Config{
+		ClientID:     os.Getenv(EnvClientID),
+		ClientSecret: os.Getenv(EnvClientSecret),
+	}
+}
+
+// Token is a set of OAuth tokens as stored in a credentials file.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Valid reports whether the access token can still be used.
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && time.Until(t.Expiry) > expiryMargin
+}
+
+// DeviceCode is a pending sign-in. The user completes it by entering
+// UserCode at VerificationURL on any device.
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURL string `json:"verification_url"`
+	ExpiresIn       int    `json:"expires_in"` // Seconds.
+	Interval        int    `json:"interval"`   // Seconds between polls.
+}
+
+// tokenResponse is the reply of the token endpoint, successful or not.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+	Description  string `json:"error_description"`
+}
+
+// StartDeviceFlow requests a device code to show to the user.
+func (cfg Config) StartDeviceFlow(ctx context.Context) (*DeviceCode, error) {
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("missing OAuth client ID")
+	}
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{ScopeYouTube}
+	}
+	var code DeviceCode
+	form := url.Values{"client_id": {cfg.ClientID}, "scope": {strings.Join(scopes, " ")}}
+	if err := cfg.postForm(ctx, DeviceCodeURL, form, &code); err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if code.DeviceCode == "" {
+		return nil, fmt.Errorf("failed to request device code: empty response")
+	}
+	return &code, nil
+}
+
+// PollToken waits until the user has completed the sign-in for code and
+// returns the tokens, polling at the interval the server asked for.
+func (cfg Config) PollToken(ctx context.Context, code *DeviceCode) (*Token, error) {
+	interval := time.Duration(max(code.Interval, 1)) * time.Second
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	form := url.Values{
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"device_code":   {code.DeviceCode},
+		"grant_type":    {"urn:ietf:params:oauth:grant-type:device_code"},
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var resp tokenResponse
+		if err := cfg.postForm(ctx, TokenURL, form, &resp); err != nil {
+			return nil, fmt.Errorf("failed to poll for token: %w", err)
+		}
+		switch resp.Error {
+		case "":
+			return resp.token(nil), nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, ErrAccessDenied
+		case "expired_token":
+			return nil, ErrCodeExpired
+		default:
+			return nil, resp.err()
+		}
+		if code.ExpiresIn > 0 && time.Now().After(deadline) {
+			return nil, ErrCodeExpired
+		}
+	}
+}
+
+// Refresh exchanges the refresh token of t for a new access token.
+func (cfg Config) Refresh(ctx context.Context, t *Token) (*Token, error) {
+	if t == nil || t.RefreshToken == "" {
+		return nil, fmt.Errorf("no refresh token; sign in again")
+	}
+	form := url.Values{
+		"client_id":     {cfg.ClientID},
+		"client_secret": {cfg.ClientSecret},
+		"refresh_token": {t.RefreshToken},
+		"grant_type":    {"refresh_token"},
+	}
+	var resp tokenResponse
+	if err := cfg.postForm(ctx, TokenURL, form, &resp); err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("failed to refresh token: %w", resp.err())
+	}
+	return resp.token(t), nil
+}
+
+// token converts a successful response. Google leaves out the refresh token
+// when refreshing, so the one of previous is kept.
+func (r tokenResponse) token(previous *Token) *Token {
+	t := &Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		TokenType:    r.TokenType,
+		Expiry:       time.Now().Add(time.Duration(r.ExpiresIn) * time.Second),
+	}
+	if t.RefreshToken == "" && previous != nil {
+		t.RefreshToken = previous.RefreshToken
+	}
+	return t
+}
+
+func (r tokenResponse) err() error {
+	if r.Description != "" {
+		return fmt.Errorf("%s: %s", r.Error, r.Description)
+	}
+	return errors.New(r.Error)
+}
+
+// postForm posts form to endpoint and decodes the JSON reply into out. Error
+// replies of the token endpoint are decoded too, since they carry the
+// polling state.
+func (cfg Config) postForm(ctx context.Context, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response (status %s): %w", resp.Status, err)
+	}
+	return nil
+}
+
+// FileTokenSource hands out access tokens from a credentials file,
+// refreshing them when they expire and writing the refreshed tokens back. It
+// is safe for concurrent use.
+type FileTokenSource struct {
+	cfg  Config
+	path string
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// NewFileTokenSource returns a token source for the credentials file at
+// path, which Login creates.
+func NewFileTokenSource(cfg Config, path string) (*FileTokenSource, error) {
+	token, err := ReadToken(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileTokenSource{cfg: cfg, path: path, token: token}, nil
+}
+
+// Token returns a valid access token.
+func (s *FileTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token.Valid() {
+		return s.token.AccessToken, nil
+	}
+	token, err := s.cfg.Refresh(context.Background(), s.token)
+	if err != nil {
+		return "", err
+	}
+	if err := WriteToken(s.path, token); err != nil {
+		return "", fmt.Errorf("failed to save refreshed token: %w", err)
+	}
+	s.token = token
+	return token.AccessToken, nil
+}
+
+// ReadToken reads a credentials file.
+func ReadToken(path string) (*Token, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+	return &token, nil
+}
+
+// WriteToken atomically replaces the credentials file at path. The file is
+// only readable by its owner, since the refresh token grants lasting access
+// to the account.
+func WriteToken(path string, token *Token) error {
+	data, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEndpoint answers the device code and token endpoints with replies
+// taken in order from a script.
+type fakeEndpoint struct {
+	mu      sync.Mutex
+	replies []string
+	forms   []url.Values
+}
+
+func (f *fakeEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.forms = append(f.forms, r.PostForm)
+	reply := `{"error":"unexpected_request"}`
+	if len(f.replies) > 0 {
+		reply, f.replies = f.replies[0], f.replies[1:]
+	}
+	w.Write([]byte(reply))
+}
+
+// testConfig returns a config whose requests to Google's endpoints go to a
+// server answering with replies.
+func testConfig(t *testing.T, replies ...string) (Config, *fakeEndpoint) {
+	endpoint := &fakeEndpoint{replies: replies}
+	srv := httptest.NewServer(endpoint)
+	t.Cleanup(srv.Close)
+	target, _ := url.Parse(srv.URL)
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	cfg := Config{ClientID: "id", ClientSecret: "secret", HTTPClient: &http.Client{Transport: transport}}
+	return cfg, endpoint
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestStartDeviceFlow(t *testing.T) {
+	cfg, endpoint := testConfig(t, `{"device_code":"dc","user_code":"ABCD-EFGH","verification_url":"https://www.google.com/device","expires_in":1800,"interval":5}`)
+	code, err := cfg.StartDeviceFlow(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.DeviceCode != "dc" || code.UserCode != "ABCD-EFGH" || code.Interval != 5 {
+		t.Errorf("code = %+v", code)
+	}
+	if got := endpoint.forms[0].Get("scope"); got != ScopeYouTube {
+		t.Errorf("scope = %q, want %q", got, ScopeYouTube)
+	}
+
+	if _, err := (Config{}).StartDeviceFlow(context.Background()); err == nil {
+		t.Error("StartDeviceFlow without client ID succeeded")
+	}
+}
+
+func TestPollToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		replies []string
+		wantErr error
+	}{
+		{
+			name:    "pending then granted",
+			replies: []string{`{"error":"authorization_pending"}`, `{"access_token":"at","refresh_token":"rt","token_type":"Bearer","expires_in":3600}`},
+		},
+		{
+			name:    "declined",
+			replies: []string{`{"error":"access_denied"}`},
+			wantErr: ErrAccessDenied,
+		},
+		{
+			name:    "expired",
+			replies: []string{`{"error":"expired_token"}`},
+			wantErr: ErrCodeExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, endpoint := testConfig(t, tt.replies...)
+			token, err := cfg.PollToken(context.Background(), &DeviceCode{DeviceCode: "dc", ExpiresIn: 60, Interval: 1})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if token.AccessToken != "at" || token.RefreshToken != "rt" || !token.Valid() {
+				t.Errorf("token = %+v", token)
+			}
+			if got := endpoint.forms[0].Get("device_code"); got != "dc" {
+				t.Errorf("device_code = %q", got)
+			}
+		})
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	expired := &Token{AccessToken: "old", RefreshToken: "rt", Expiry: time.Now().Add(-time.Hour)}
+	if err := WriteToken(path, expired); err != nil {
+		t.Fatal(err)
+	}
+
+	// Google leaves the refresh token out of refresh replies.
+	cfg, endpoint := testConfig(t, `{"access_token":"new","token_type":"Bearer","expires_in":3600}`)
+	source, err := NewFileTokenSource(cfg, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "new" {
+			t.Errorf("Token() = %q, want new", token)
+		}
+	}
+	if len(endpoint.forms) != 1 || endpoint.forms[0].Get("refresh_token") != "rt" {
+		t.Errorf("refresh requests = %v, want one with the stored refresh token", endpoint.forms)
+	}
+
+	saved, err := ReadToken(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken != "new" || saved.RefreshToken != "rt" {
+		t.Errorf("saved token = %+v, want refreshed access token and kept refresh token", saved)
+	}
+}
@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+// Embedder turns texts into embedding vectors, one per input text and in the
+// same order.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbedderFunc adapts a function to the Embedder interface.
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+// Embed implements Embedder.
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return f(ctx, texts)
+}
+
+// EmbeddingRecord is one line of the JSONL written by WriteEmbeddingsJSONL.
+type EmbeddingRecord struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	Embedding []float32 `json:"embedding"`
+	VideoID   string    `json:"video_id"`
+	Start     float64   `json:"start"`
+	End       float64   `json:"end"`
+}
+
+// EmbedOptions controls how WriteEmbeddingsJSONL chunks a transcript.
+type EmbedOptions struct {
+	Window    time.Duration // Time covered by each chunk. Defaults to one minute.
+	Overlap   time.Duration // Overlap between consecutive chunks.
+	BatchSize int           // Chunks sent to the embedder per call. Defaults to 32.
+}
+
+const (
+	defaultEmbedWindow    = time.Minute
+	defaultEmbedBatchSize = 32
+)
+
+// WriteEmbeddingsJSONL splits the transcript into overlapping time windows,
+// embeds each chunk and writes one EmbeddingRecord per line to w. Record IDs
+// combine the video ID and chunk number so that re-exporting a video
+// overwrites its previous records in most vector stores.
+func WriteEmbeddingsJSONL(ctx context.Context, w io.Writer, transcript *yttranscript.Transcript, embedder Embedder, opts EmbedOptions) error {
+	if opts.Window <= 0 {
+		opts.Window = defaultEmbedWindow
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultEmbedBatchSize
+	}
+
+	chunks := transcript.Rechunk(opts.Window, opts.Overlap).Texts
+	encoder := json.NewEncoder(w)
+	for from := 0; from < len(chunks); from += opts.BatchSize {
+		batch := chunks[from:min(from+opts.BatchSize, len(chunks))]
+		inputs := make([]string, len(batch))
+		for i, chunk := range batch {
+			inputs[i] = chunk.Content
+		}
+
+		embeddings, err := embedder.Embed(ctx, inputs)
+		if err != nil {
+			return fmt.Errorf("failed to embed chunks %d-%d: %w", from, from+len(batch)-1, err)
+		}
+		if len(embeddings) != len(batch) {
+			return fmt.Errorf("embedder returned %d embeddings for %d chunks", len(embeddings), len(batch))
+		}
+
+		for i, chunk := range batch {
+			record := EmbeddingRecord{
+				ID:        fmt.Sprintf("%s-%d", transcript.VideoID, from+i),
+				Text:      chunk.Content,
+				Embedding: embeddings[i],
+				VideoID:   transcript.VideoID,
+				Start:     chunk.Start,
+				End:       chunk.End(),
+			}
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to write embedding record: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Environment variables read by OpenAICompletionFromEnv.
+const (
+	EnvBaseURL = "OPENAI_BASE_URL"
+	EnvAPIKey  = "OPENAI_API_KEY"
+	EnvModel   = "OPENAI_MODEL"
+
+	EnvEmbeddingModel = "OPENAI_EMBEDDING_MODEL"
+)
+
+const (
+	defaultOpenAIBaseURL = "https://api.openai.com/v1"
+	defaultOpenAIModel   = "gpt-4o-mini"
+
+	defaultOpenAIEmbeddingModel = "text-embedding-3-small"
+)
+
+// OpenAICompletion returns a CompletionFunc that calls the chat completions
+// endpoint of an OpenAI-compatible API at baseURL.
+func OpenAICompletion(httpClient *http.Client, baseURL, apiKey, model string) CompletionFunc {
+	endpoint := strings.TrimRight(baseURL, "/") + "/chat/completions"
+	return func(ctx context.Context, prompt string) (string, error) {
+		payload := map[string]interface{}{
+			"model": model,
+			"messages": []map[string]string{
+				{"role": "user", "content": prompt},
+			},
+		}
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal completion request: %w", err)
+		}
+
+		var completion struct {
+			Choices []struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+			} `json:"choices"`
+		}
+		if err := postJSON(ctx, httpClient, endpoint, apiKey, payloadBytes, &completion); err != nil {
+			return "", fmt.Errorf("failed to request completion: %w", err)
+		}
+		if len(completion.Choices) == 0 {
+			return "", fmt.Errorf("completion response has no choices")
+		}
+		return completion.Choices[0].Message.Content, nil
+	}
+}
+
+// OpenAICompletionFromEnv configures OpenAICompletion from OPENAI_BASE_URL
+// (default https://api.openai.com/v1), OPENAI_API_KEY and OPENAI_MODEL
+// (default gpt-4o-mini). The key may be empty for local servers that do not
+// require one.
+func OpenAICompletionFromEnv() CompletionFunc {
+	return OpenAICompletion(http.DefaultClient,
+		orDefault(os.Getenv(EnvBaseURL), defaultOpenAIBaseURL),
+		os.Getenv(EnvAPIKey),
+		orDefault(os.Getenv(EnvModel), defaultOpenAIModel))
+}
+
+// OpenAIEmbedder returns an Embedder that calls the embeddings endpoint of an
+// OpenAI-compatible API at baseURL.
+func OpenAIEmbedder(httpClient *http.Client, baseURL, apiKey, model string) Embedder {
+	endpoint := strings.TrimRight(baseURL, "/") + "/embeddings"
+	return EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		payload := map[string]interface{}{
+			"model": model,
+			"input": texts,
+		}
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal embedding request: %w", err)
+		}
+
+		var response struct {
+			Data []struct {
+				Index     int       `json:"index"`
+				Embedding []float32 `json:"embedding"`
+			} `json:"data"`
+		}
+		if err := postJSON(ctx, httpClient, endpoint, apiKey, payloadBytes, &response); err != nil {
+			return nil, fmt.Errorf("failed to request embeddings: %w", err)
+		}
+
+		embeddings := make([][]float32, len(texts))
+		for _, item := range response.Data {
+			if item.Index < 0 || item.Index >= len(texts) {
+				return nil, fmt.Errorf("embedding index %d out of range", item.Index)
+			}
+			embeddings[item.Index] = item.Embedding
+		}
+		return embeddings, nil
+	})
+}
+
+// OpenAIEmbedderFromEnv configures OpenAIEmbedder from OPENAI_BASE_URL,
+// OPENAI_API_KEY and OPENAI_EMBEDDING_MODEL (default text-embedding-3-small).
+func OpenAIEmbedderFromEnv() Embedder {
+	return OpenAIEmbedder(http.DefaultClient,
+		orDefault(os.Getenv(EnvBaseURL), defaultOpenAIBaseURL),
+		os.Getenv(EnvAPIKey),
+		orDefault(os.Getenv(EnvEmbeddingModel), defaultOpenAIEmbeddingModel))
+}
+
+// postJSON posts payload to endpoint and decodes the JSON reply into out.
+func postJSON(ctx context.Context, httpClient *http.Client, endpoint, apiKey string, payload []byte, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad status: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
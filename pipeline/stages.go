@@ -0,0 +1,154 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"yt-transcript/export"
+	"yt-transcript/yttranscript"
+)
+
+// Item is one video travelling through a pipeline. Stages fill in Transcript
+// and Output as it moves along. An item whose Err is set is passed through
+// untouched by the remaining stages so that the sink can report it.
+type Item struct {
+	VideoID    string
+	Transcript *yttranscript.Transcript
+	Output     []byte
+	Err        error
+}
+
+// Stage consumes items from in and returns the channel it emits them on. A
+// stage must close its output once in is closed, and must keep reading in
+// until then even when ctx is done, dropping items instead of processing
+// them.
+type Stage func(ctx context.Context, in <-chan *Item) <-chan *Item
+
+// DefaultBuffer is the capacity of the channel between two stages. Small
+// buffers keep memory bounded: when a slow stage falls behind, the stages
+// before it block instead of piling up work.
+const DefaultBuffer = 16
+
+// Map returns a stage running fn on every item with concurrency workers.
+// Items already carrying an error skip fn. With more than one worker, items
+// may leave in a different order than they arrived.
+func Map(concurrency int, fn func(ctx context.Context, item *Item) error) Stage {
+	concurrency = max(concurrency, 1)
+	return func(ctx context.Context, in <-chan *Item) <-chan *Item {
+		out := make(chan *Item, DefaultBuffer)
+		var wg sync.WaitGroup
+		for range concurrency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// After cancellation keep draining in, dropping items, so
+				// that upstream stages never block forever.
+				for item := range in {
+					if ctx.Err() != nil {
+						continue
+					}
+					if item.Err == nil {
+						item.Err = fn(ctx, item)
+					}
+					select {
+					case out <- item:
+					case <-ctx.Done():
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+		return out
+	}
+}
+
+// Fetch returns a stage that downloads each video's transcript in
+// languageCode with the given number of concurrent requests.
+func Fetch(client *yttranscript.Client, languageCode string, concurrency int, opts ...yttranscript.CallOption) Stage {
+	return Map(concurrency, func(ctx context.Context, item *Item) error {
+		transcript, err := client.GetTranscript(item.VideoID, languageCode, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to get transcript: %w", err)
+		}
+		item.Transcript = transcript
+		return nil
+	})
+}
+
+// Transform returns a stage replacing each transcript with fn's result, for
+// cleaning, deduplication or enrichment.
+func Transform(fn func(*yttranscript.Transcript) (*yttranscript.Transcript, error)) Stage {
+	return Map(1, func(ctx context.Context, item *Item) error {
+		transcript, err := fn(item.Transcript)
+		if err != nil {
+			return err
+		}
+		item.Transcript = transcript
+		return nil
+	})
+}
+
+// Format returns a stage rendering each transcript into Output with write,
+// for example an entry of export.Formats.
+func Format(write export.WriterFunc) Stage {
+	return Map(1, func(ctx context.Context, item *Item) error {
+		var buf bytes.Buffer
+		if err := write(&buf, item.Transcript); err != nil {
+			return fmt.Errorf("failed to render transcript: %w", err)
+		}
+		item.Output = buf.Bytes()
+		return nil
+	})
+}
+
+// Source emits an item per video ID.
+func Source(ctx context.Context, videoIDs []string) <-chan *Item {
+	out := make(chan *Item, DefaultBuffer)
+	go func() {
+		defer close(out)
+		for _, videoID := range videoIDs {
+			select {
+			case out <- &Item{VideoID: videoID}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Compose chains stages into one.
+func Compose(stages ...Stage) Stage {
+	return func(ctx context.Context, in <-chan *Item) <-chan *Item {
+		for _, stage := range stages {
+			in = stage(ctx, in)
+		}
+		return in
+	}
+}
+
+// Run feeds source through the stages and hands every resulting item,
+// including failed ones, to sink one at a time. It stops at the first error
+// sink returns, cancelling the stages, and otherwise returns ctx's error, if
+// any, once all items have been delivered.
+func Run(ctx context.Context, source <-chan *Item, sink func(*Item) error, stages ...Stage) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := Compose(stages...)(ctx, source)
+	for item := range out {
+		if err := sink(item); err != nil {
+			cancel()
+			for range out {
+				// Drain so that stage goroutines can exit.
+			}
+			return err
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,94 @@
+// Package pipeline post-processes transcripts with language models.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"yt-transcript/tokenizer"
+	"yt-transcript/yttranscript"
+)
+
+// CompletionFunc sends a prompt to a language model and returns its reply.
+type CompletionFunc func(ctx context.Context, prompt string) (string, error)
+
+// Summarizer produces a summary of a transcript.
+type Summarizer interface {
+	Summarize(ctx context.Context, transcript *yttranscript.Transcript) (string, error)
+}
+
+// Default prompts used by ChunkedSummarizer. Each contains one %s verb.
+const (
+	DefaultChunkPrompt = "Summarize the following part of a video transcript in a few sentences. " +
+		"Lines start with their timestamp; mention timestamps of key points.\n\n%s"
+	DefaultMergePrompt = "The following are summaries of consecutive parts of one video. " +
+		"Combine them into a single coherent summary, keeping the timestamps of key points.\n\n%s"
+)
+
+// defaultChunkTokens leaves room for the prompt and reply in a 4k context.
+const defaultChunkTokens = 3000
+
+// ChunkedSummarizer summarizes transcripts too long for one prompt by
+// splitting them into chunks that fit a token budget, summarizing each chunk,
+// and then asking the model to merge the partial summaries.
+type ChunkedSummarizer struct {
+	Complete    CompletionFunc
+	ChunkTokens int                 // Token budget per chunk. Defaults to 3000.
+	Tokenizer   tokenizer.Tokenizer // Defaults to tokenizer.CL100K.
+	ChunkPrompt string              // Defaults to DefaultChunkPrompt.
+	MergePrompt string              // Defaults to DefaultMergePrompt.
+}
+
+// Summarize implements Summarizer.
+func (s *ChunkedSummarizer) Summarize(ctx context.Context, transcript *yttranscript.Transcript) (string, error) {
+	if s.Complete == nil {
+		return "", fmt.Errorf("no completion function configured")
+	}
+	tok := s.Tokenizer
+	if tok == nil {
+		tok = tokenizer.CL100K{}
+	}
+	chunkTokens := s.ChunkTokens
+	if chunkTokens <= 0 {
+		chunkTokens = defaultChunkTokens
+	}
+
+	// Merge lines with their timestamps attached so the budget accounts
+	// for them.
+	lines := make([]yttranscript.Text, len(transcript.Texts))
+	for i, text := range transcript.Texts {
+		lines[i] = text
+		lines[i].Content = fmt.Sprintf("[%s] %s\n", yttranscript.FormatTimestamp(text.Start), text.Content)
+	}
+	timestamped := &yttranscript.Transcript{Texts: lines}
+	chunks := timestamped.MergeBy(chunkTokens, tok.Count).Texts
+	if len(chunks) == 0 {
+		return "", yttranscript.ErrEmptyTranscript
+	}
+
+	summaries := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		summary, err := s.Complete(ctx, fmt.Sprintf(orDefault(s.ChunkPrompt, DefaultChunkPrompt), chunk.Content))
+		if err != nil {
+			return "", fmt.Errorf("failed to summarize chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+		summaries = append(summaries, strings.TrimSpace(summary))
+	}
+	if len(summaries) == 1 {
+		return summaries[0], nil
+	}
+
+	merged, err := s.Complete(ctx, fmt.Sprintf(orDefault(s.MergePrompt, DefaultMergePrompt), strings.Join(summaries, "\n\n")))
+	if err != nil {
+		return "", fmt.Errorf("failed to merge summaries: %w", err)
+	}
+	return strings.TrimSpace(merged), nil
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
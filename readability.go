@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"yt-transcript/analyze"
+	"yt-transcript/yttranscript"
+)
+
+// runReadability prints readability metrics for a transcript and each of
+// its chapters.
+func runReadability(args []string) {
+	fs := flag.NewFlagSet("readability", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print metrics as JSON")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 {
+		log.Fatal(usage)
+	}
+	videoID, languageCode := videoIDArg(fs.Arg(0)), fs.Arg(1)
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	overall := analyze.ReadabilityOf(transcript)
+	chapters := analyze.ReadabilityByChapter(transcript)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(struct {
+			analyze.Readability
+			Chapters []analyze.ChapterReadability `json:"chapters,omitempty"`
+		}{overall, chapters}); err != nil {
+			log.Fatalf("Failed to write metrics: %v", err)
+		}
+		return
+	}
+
+	printReadability("Overall", overall)
+	for _, chapter := range chapters {
+		printReadability(fmt.Sprintf("[%s] %s", yttranscript.FormatTimestamp(chapter.Chapter.Start), chapter.Chapter.Title), chapter.Readability)
+	}
+}
+
+func printReadability(label string, r analyze.Readability) {
+	fmt.Printf("%s: reading ease %.1f (%s)", label, r.ReadingEase, r.Formula)
+	if r.Grade != 0 {
+		fmt.Printf(", grade %.1f", r.Grade)
+	}
+	fmt.Printf(", %d words, %.1f words/sentence, %.2f syllables/word", r.Words, r.WordsPerSentence, r.SyllablesPerWord)
+	if r.SentencesEstimated {
+		fmt.Print(" (no punctuation, caption lines counted as sentences)")
+	}
+	fmt.Println()
+}
@@ -0,0 +1,257 @@
+// Package replay records every HTTP response a client receives into a
+// content-addressed directory and serves them back later, so that a run can
+// be repeated byte for byte from its recorded inputs without the network.
+//
+// A recording directory holds a manifest.jsonl file listing each exchange in
+// the order it happened, and an objects directory holding every response,
+// named after the SHA-256 hash of its contents.
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"yt-transcript/internal/atomicfile"
+)
+
+const (
+	manifestName = "manifest.jsonl"
+	objectsDir   = "objects"
+)
+
+// ErrNotRecorded is returned by a Replayer for a request that is not in the
+// recording, or that was made more often than during recording.
+var ErrNotRecorded = errors.New("request not recorded")
+
+// Exchange is one line of the manifest.
+type Exchange struct {
+	Method        string `json:"method"`
+	URL           string `json:"url"`
+	RequestHash   string `json:"request_sha256"` // Hash of the request body.
+	ResponseHash  string `json:"response_sha256"`
+	StatusCode    int    `json:"status"`
+	ContentLength int    `json:"content_length"`
+}
+
+// key identifies requests that are answered the same way.
+func (e Exchange) key() string {
+	return e.Method + " " + e.URL + " " + e.RequestHash
+}
+
+// Recorder is an http.RoundTripper that passes requests to Base and records
+// every response. A Recorder is safe for concurrent use.
+type Recorder struct {
+	Base http.RoundTripper // Defaults to http.DefaultTransport.
+
+	dir string
+	mu  sync.Mutex // Serializes manifest writes.
+}
+
+// NewRecorder returns a Recorder writing to dir on top of base. Exchanges
+// are appended to an existing recording in dir.
+func NewRecorder(dir string, base http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Join(dir, objectsDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	return &Recorder{Base: base, dir: dir}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestHash, req, err := hashRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	base := r.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	entry := *resp
+	entry.Header = resp.Header.Clone()
+	entry.Body = io.NopCloser(bytes.NewReader(body))
+	entry.ContentLength = int64(len(body))
+	entry.TransferEncoding = nil
+	entry.Header.Del("Content-Encoding") // The body is already decoded.
+	dump, err := httputil.DumpResponse(&entry, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize response: %w", err)
+	}
+
+	exchange := Exchange{
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHash:   requestHash,
+		ResponseHash:  hashHex(dump),
+		StatusCode:    resp.StatusCode,
+		ContentLength: len(body),
+	}
+	if err := r.record(exchange, dump); err != nil {
+		return nil, fmt.Errorf("failed to record response: %w", err)
+	}
+	return resp, nil
+}
+
+func (r *Recorder) record(exchange Exchange, dump []byte) error {
+	path := filepath.Join(r.dir, objectsDir, exchange.ResponseHash)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := atomicfile.Write(path, dump); err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(exchange)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f, err := os.OpenFile(filepath.Join(r.dir, manifestName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Replayer is an http.RoundTripper answering requests from a recording
+// without touching the network. Requests made several times are answered
+// with the responses recorded for them in order. A Replayer is safe for
+// concurrent use.
+type Replayer struct {
+	dir string
+
+	mu      sync.Mutex
+	pending map[string][]Exchange // Unplayed exchanges by Exchange.key.
+}
+
+// NewReplayer loads the recording in dir.
+func NewReplayer(dir string) (*Replayer, error) {
+	exchanges, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	r := &Replayer{dir: dir, pending: make(map[string][]Exchange)}
+	for _, e := range exchanges {
+		r.pending[e.key()] = append(r.pending[e.key()], e)
+	}
+	return r, nil
+}
+
+// ReadManifest returns the exchanges of the recording in dir in the order
+// they were recorded.
+func ReadManifest(dir string) ([]Exchange, error) {
+	f, err := os.Open(filepath.Join(dir, manifestName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open manifest: %w", err)
+	}
+	defer f.Close()
+
+	var exchanges []Exchange
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Exchange
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal manifest line %d: %w", len(exchanges)+1, err)
+		}
+		exchanges = append(exchanges, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return exchanges, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestHash, _, err := hashRequestBody(req)
+	if req.Body != nil {
+		req.Body.Close() // Never sent, but a RoundTripper must close it.
+	}
+	if err != nil {
+		return nil, err
+	}
+	key := Exchange{Method: req.Method, URL: req.URL.String(), RequestHash: requestHash}.key()
+
+	r.mu.Lock()
+	queue := r.pending[key]
+	if len(queue) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, req.URL)
+	}
+	exchange := queue[0]
+	r.pending[key] = queue[1:]
+	r.mu.Unlock()
+
+	dump, err := os.ReadFile(filepath.Join(r.dir, objectsDir, exchange.ResponseHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded response: %w", err)
+	}
+	if hashHex(dump) != exchange.ResponseHash {
+		return nil, fmt.Errorf("recorded response %s is corrupt", exchange.ResponseHash)
+	}
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(dump)), req)
+}
+
+// hashRequestBody hashes the body of req without modifying req. It returns
+// the request to send in its place: req itself when the body could be read
+// through GetBody, or else a clone carrying the body it consumed.
+func hashRequestBody(req *http.Request) (string, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return hashHex(nil), req, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get request body: %w", err)
+		}
+		data, err := io.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		return hashHex(data), req, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(data))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return hashHex(data), out, nil
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
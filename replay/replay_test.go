@@ -0,0 +1,136 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHashRequestBody(t *testing.T) {
+	const payload = `{"videoId":"x"}`
+	tests := []struct {
+		name        string
+		body        io.Reader
+		want        string
+		wantGetBody bool // Whether the request can be hashed without consuming it.
+	}{
+		{name: "no body", want: hashHex(nil)},
+		{name: "empty body", body: http.NoBody, want: hashHex(nil)},
+		{name: "replayable body", body: strings.NewReader(payload), want: hashHex([]byte(payload)), wantGetBody: true},
+		{name: "one-shot body", body: io.NopCloser(strings.NewReader(payload)), want: hashHex([]byte(payload))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "https://example.com/", tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (req.GetBody != nil) != tt.wantGetBody && tt.body != nil && tt.body != http.NoBody {
+				t.Fatalf("GetBody set = %v, test assumes %v", req.GetBody != nil, tt.wantGetBody)
+			}
+			original, originalBody := *req, req.Body
+
+			got, out, err := hashRequestBody(req)
+			if err != nil || got != tt.want {
+				t.Fatalf("hashRequestBody = %s, %v, want %s", got, err, tt.want)
+			}
+			if req.Body != originalBody || req.GetBody == nil != (original.GetBody == nil) {
+				t.Errorf("hashRequestBody modified the request")
+			}
+			if tt.wantGetBody && out != req {
+				t.Errorf("request with GetBody was cloned")
+			}
+			if out.Body == nil {
+				return
+			}
+			sent, err := io.ReadAll(out.Body)
+			if err != nil || hashHex(sent) != tt.want {
+				t.Errorf("forwarded body = %q, %v, want the hashed body", sent, err)
+			}
+		})
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprintf(w, "%s %s %s #%d", r.Method, r.URL.Path, body, n)
+	}))
+
+	type exchange struct {
+		method, path, body string
+	}
+	exchanges := []exchange{
+		{http.MethodGet, "/watch", ""},
+		{http.MethodPost, "/player", `{"videoId":"a"}`},
+		{http.MethodPost, "/player", `{"videoId":"b"}`},
+		{http.MethodGet, "/watch", ""},
+	}
+	do := func(client *http.Client, e exchange) (string, error) {
+		req, err := http.NewRequest(e.method, server.URL+e.path, strings.NewReader(e.body))
+		if err != nil {
+			return "", err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded []string
+	for _, e := range exchanges {
+		body, err := do(&http.Client{Transport: recorder}, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorded = append(recorded, body)
+	}
+	server.Close()
+
+	manifest, err := ReadManifest(dir)
+	if err != nil || len(manifest) != len(exchanges) {
+		t.Fatalf("ReadManifest = %d exchanges, %v, want %d", len(manifest), err, len(exchanges))
+	}
+	if manifest[1].RequestHash == manifest[2].RequestHash {
+		t.Errorf("requests with different bodies have the same hash")
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: replayer}
+	// Replay out of order: requests are matched by method, URL and body,
+	// and repeated requests get their responses in recorded order.
+	for _, i := range []int{2, 0, 1, 3} {
+		body, err := do(client, exchanges[i])
+		if err != nil {
+			t.Fatalf("replaying %v: %v", exchanges[i], err)
+		}
+		if body != recorded[i] {
+			t.Errorf("replayed %v = %q, want %q", exchanges[i], body, recorded[i])
+		}
+	}
+	if _, err := do(client, exchanges[0]); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("third GET /watch: err = %v, want ErrNotRecorded", err)
+	}
+	if _, err := do(client, exchange{http.MethodPost, "/player", `{"videoId":"c"}`}); !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("unrecorded body: err = %v, want ErrNotRecorded", err)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"yt-transcript/yttranscript"
+)
+
+// runResolve finds a video of a channel by an approximate title and prints
+// its ID, or the best candidates with -n.
+func runResolve(args []string) {
+	fs := flag.NewFlagSet("resolve", flag.ExitOnError)
+	count := fs.Int("n", 0, "print this many candidates with their scores instead of the best match's ID")
+	fs.Parse(args)
+	if fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+	channel, title := fs.Arg(0), strings.Join(fs.Args()[1:], " ")
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	if *count <= 0 {
+		videoID, err := client.ResolveVideo(channel, title)
+		if err != nil {
+			log.Fatalf("Failed to resolve video: %v", err)
+		}
+		fmt.Println(videoID)
+		return
+	}
+
+	matches, err := client.SearchChannelVideos(channel, title)
+	if err != nil {
+		log.Fatalf("Failed to search channel: %v", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, match := range matches[:min(*count, len(matches))] {
+		fmt.Fprintf(w, "%s\t%.2f\t%s\n", match.VideoID, match.Score, match.Title)
+	}
+	w.Flush()
+}
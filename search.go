@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"yt-transcript/yttranscript"
+)
+
+// runSearch prints every line of a transcript that contains the query,
+// prefixed with its timestamp and, with -links, followed by a link that
+// opens the video there.
+func runSearch(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	links := fs.Bool("links", false, "follow every line with a link to that point in the video")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+	videoID, query, languageCode := videoIDArg(fs.Arg(0)), fs.Arg(1), fs.Arg(2)
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	matches := transcript.Search(query)
+	if len(matches) == 0 {
+		fmt.Printf("No matches found for %q.\n", query)
+		return
+	}
+	for _, match := range matches {
+		if *links {
+			fmt.Printf("[%s] %s %s\n", yttranscript.FormatTimestamp(match.Start), match.Text, match.Link())
+			continue
+		}
+		fmt.Printf("[%s] %s\n", yttranscript.FormatTimestamp(match.Start), match.Text)
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"yt-transcript/yttranscript"
+)
+
+// printStats prints the statistics of a transcript for -stats.
+func printStats(transcript *yttranscript.Transcript) {
+	stats := transcript.Stats()
+	fmt.Printf("\nStatistics (%s):\n", transcript.LanguageCode)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Segments:\t%d\n", stats.Segments)
+	fmt.Fprintf(w, "Words:\t%d\n", stats.Words)
+	fmt.Fprintf(w, "Span:\t%s\n", yttranscript.FormatTimestamp(stats.Span))
+	fmt.Fprintf(w, "Covered:\t%s (%.0f%%)\n", yttranscript.FormatTimestamp(stats.Covered), stats.Coverage*100)
+	fmt.Fprintf(w, "Speaking rate:\t%.0f words per minute\n", stats.WordsPerMinute)
+	var gaps []string
+	for _, gap := range stats.LongestGaps {
+		gaps = append(gaps, fmt.Sprintf("%s (%.1fs)", yttranscript.FormatTimestamp(gap.Start), gap.Duration()))
+	}
+	if len(gaps) == 0 {
+		gaps = append(gaps, "none")
+	}
+	fmt.Fprintf(w, "Longest gaps:\t%s\n", strings.Join(gaps, ", "))
+	w.Flush()
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"yt-transcript/pipeline"
+	"yt-transcript/yttranscript"
+)
+
+// runSummarize summarizes a transcript with an OpenAI-compatible endpoint
+// configured through the OPENAI_* environment variables.
+func runSummarize(args []string) {
+	if len(args) < 1 {
+		log.Fatal(usage)
+	}
+	videoID := videoIDArg(args[0])
+	languageCode := ""
+	if len(args) > 1 {
+		languageCode = args[1]
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	summarizer := &pipeline.ChunkedSummarizer{Complete: pipeline.OpenAICompletionFromEnv()}
+	summary, err := summarizer.Summarize(context.Background(), transcript)
+	if err != nil {
+		log.Fatalf("Failed to summarize transcript: %v", err)
+	}
+	fmt.Println(summary)
+}
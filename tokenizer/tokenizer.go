@@ -0,0 +1,100 @@
+// Package tokenizer counts tokens in transcript text so that LLM users can
+// budget context windows.
+package tokenizer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"yt-transcript/yttranscript"
+)
+
+// Tokenizer counts the tokens in a piece of text.
+type Tokenizer interface {
+	Count(text string) int
+}
+
+// Whitespace counts whitespace-separated words.
+type Whitespace struct{}
+
+// Count returns the number of whitespace-separated words in text.
+func (Whitespace) Count(text string) int {
+	return len(strings.Fields(text))
+}
+
+// cl100kPattern is the pre-tokenization pattern of the cl100k_base encoding,
+// minus the trailing-whitespace lookahead that RE2 does not support.
+var cl100kPattern = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`)
+
+// CL100K estimates token counts for cl100k_base style BPE encodings, as used
+// by GPT-3.5 and GPT-4. Text is split with the encoding's own pre-tokenizer;
+// each piece is then assumed to merge into one token per few characters.
+// Without the vocabulary the result is an estimate, typically within a few
+// percent for English speech.
+type CL100K struct{}
+
+// cl100kCharsPerToken is the average number of characters a single BPE token
+// covers within a pre-tokenized piece of text.
+const cl100kCharsPerToken = 6
+
+// Count returns the estimated number of cl100k_base tokens in text.
+func (CL100K) Count(text string) int {
+	count := 0
+	for _, piece := range cl100kPattern.FindAllString(text, -1) {
+		n := utf8.RuneCountInString(piece)
+		if !isASCII(piece) {
+			// Non-ASCII text is split into far smaller tokens.
+			count += n
+			continue
+		}
+		count += (n + cl100kCharsPerToken - 1) / cl100kCharsPerToken
+	}
+	return count
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizers maps the names accepted by ByName to tokenizers.
+var tokenizers = map[string]Tokenizer{
+	"whitespace": Whitespace{},
+	"cl100k":     CL100K{},
+}
+
+// ByName returns the tokenizer registered under name.
+func ByName(name string) (Tokenizer, error) {
+	tok, ok := tokenizers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown tokenizer %q, expected one of: %s", name, strings.Join(Names(), ", "))
+	}
+	return tok, nil
+}
+
+// Names returns the names of all registered tokenizers in sorted order.
+func Names() []string {
+	names := make([]string, 0, len(tokenizers))
+	for name := range tokenizers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CountTranscript returns the number of tokens in the transcript's text, with
+// lines joined by single spaces.
+func CountTranscript(tok Tokenizer, transcript *yttranscript.Transcript) int {
+	lines := make([]string, len(transcript.Texts))
+	for i, text := range transcript.Texts {
+		lines[i] = text.Content
+	}
+	return tok.Count(strings.Join(lines, " "))
+}
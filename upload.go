@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"yt-transcript/export"
+	"yt-transcript/export/objectstore"
+	"yt-transcript/yttranscript"
+)
+
+// runUpload renders a transcript and uploads it to an S3-compatible bucket,
+// with credentials taken from the AWS_* environment variables.
+func runUpload(args []string) {
+	fs := flag.NewFlagSet("upload", flag.ExitOnError)
+	format := fs.String("format", "json", "export format: "+strings.Join(export.FormatNames(), ", "))
+	endpoint := fs.String("endpoint", "", "object storage endpoint, e.g. "+objectstore.EndpointGCS+" (default the Amazon S3 endpoint of the region)")
+	region := fs.String("region", "", "bucket region (default $AWS_REGION or us-east-1; auto for GCS)")
+	keyTemplate := fs.String("key", objectstore.DefaultKeyTemplate, "object key template")
+	computed := fs.Bool("computed", false, "add index, end time and character/word counts to csv, tsv and json output")
+	annotate := fs.Bool("annotate", false, "mark automatic captions and machine translations in json, markdown and ass output")
+	sections := fs.Bool("sections", false, "add a heading at every topic change to markdown output of videos without chapters")
+	nice := registerSingleNiceFlag(fs)
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+	bucket, videoID, languageCode := fs.Arg(0), videoIDArg(fs.Arg(1)), fs.Arg(2)
+	applyNice(*nice)
+
+	cfg := objectstore.ConfigFromEnv(bucket)
+	cfg.Endpoint = *endpoint
+	cfg.KeyTemplate = *keyTemplate
+	if *region != "" {
+		cfg.Region = *region
+	}
+	store, err := objectstore.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to configure object store: %v", err)
+	}
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	opts := export.Options{ComputedFields: *computed, Annotate: *annotate, Sections: *sections}
+	key, err := store.Upload(context.Background(), transcript, *format, opts)
+	if err != nil {
+		log.Fatalf("Failed to upload transcript: %v", err)
+	}
+	fmt.Printf("Uploaded s3://%s/%s\n", bucket, key)
+}
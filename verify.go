@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"yt-transcript/yttranscript"
+)
+
+// runVerify reports whether a quote appears in a video's transcript, and
+// when, or shows the closest passage if it does not.
+func runVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	minScore := fs.Float64("min", 0.8, "lowest similarity score, from 0 to 1, counted as a match")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		log.Fatal(usage)
+	}
+	videoID, quote, languageCode := videoIDArg(fs.Arg(0)), fs.Arg(1), fs.Arg(2)
+
+	client, err := yttranscript.New()
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	transcript, err := client.GetTranscript(videoID, languageCode)
+	if err != nil {
+		log.Fatalf("Failed to get transcript: %v", err)
+	}
+
+	candidates := transcript.FindQuote(quote, 0)
+	var found []yttranscript.QuoteMatch
+	for _, candidate := range candidates {
+		if candidate.Score >= *minScore {
+			found = append(found, candidate)
+		}
+	}
+
+	if len(found) == 0 {
+		fmt.Println("Quote not found.")
+		if len(candidates) > 0 {
+			fmt.Println("Closest passage:")
+			printQuoteMatch(candidates[0])
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("Quote found %d time(s):\n", len(found))
+	for _, match := range found {
+		printQuoteMatch(match)
+	}
+}
+
+func printQuoteMatch(match yttranscript.QuoteMatch) {
+	fmt.Printf("[%s-%s] score %.2f  %s\n    %s\n",
+		yttranscript.FormatTimestamp(match.Start), yttranscript.FormatTimestamp(match.End),
+		match.Score, match.Link(), match.Text)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+)
+
+// runWatch polls a channel's RSS feed on an interval until interrupted,
+// fetching the transcripts of new uploads once their captions appear. A stop
+// signal ends the watch after the video being fetched.
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	channelID := fs.String("channel", "", "ID of the channel to watch")
+	interval := fs.Duration("interval", 15*time.Minute, "time between polls of the channel feed")
+	flags := registerFeedFlags(fs)
+	fs.Parse(args)
+
+	if *channelID == "" || *interval <= 0 {
+		log.Fatal(usage)
+	}
+	interrupt = watchInterrupts()
+	job := flags.job(*channelID, fs.Arg(0))
+	if *flags.dryRun {
+		job.printEstimate(*interval)
+		return
+	}
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		// A failed poll, for example during a network outage, is retried on
+		// the next tick rather than ending the watch.
+		if err := job.poll(); err != nil {
+			log.Printf("Warning: failed to poll channel feed: %v", err)
+		}
+		exitIfInterrupted()
+		select {
+		case <-ticker.C:
+		case <-interrupt.Done():
+			exitIfInterrupted()
+		}
+	}
+}
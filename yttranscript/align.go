@@ -0,0 +1,53 @@
+package yttranscript
+
+import (
+	"math"
+	"strings"
+)
+
+// maxAlignGap is how far, in seconds, a segment of the other track that
+// overlaps no segment of the base track may lie from the nearest one and
+// still be paired with it.
+const maxAlignGap = 2.0
+
+// AlignTranscripts pairs two independently timed tracks of a video, such as
+// the manual English and Spanish captions, for side-by-side reading. Unlike
+// the machine translations of GetTranscriptPair, such tracks split lines
+// differently: each segment of other goes to the segment of base it overlaps
+// longest, or else to the nearest one within two seconds, and a base segment
+// receives all segments assigned to it joined in order. The result's
+// Translated.Texts is index-aligned with base.Texts and takes its timing; base
+// segments matched by nothing get empty content.
+func AlignTranscripts(base, other *Transcript) *TranscriptPair {
+	assigned := make([][]string, len(base.Texts))
+	from := 0 // First base segment that may still overlap, as both tracks run in time order.
+	for _, text := range other.Texts {
+		if strings.TrimSpace(text.Content) == "" {
+			continue
+		}
+		for from < len(base.Texts) && base.Texts[from].End()+maxAlignGap < text.Start {
+			from++
+		}
+
+		best, bestOverlap, bestGap := -1, 0.0, math.Inf(1)
+		for i := from; i < len(base.Texts) && base.Texts[i].Start <= text.End()+maxAlignGap; i++ {
+			candidate := base.Texts[i]
+			overlap := min(candidate.End(), text.End()) - max(candidate.Start, text.Start)
+			switch {
+			case overlap > bestOverlap:
+				best, bestOverlap = i, overlap
+			case bestOverlap == 0 && overlap <= 0 && -overlap < bestGap:
+				best, bestGap = i, -overlap
+			}
+		}
+		if best >= 0 && (bestOverlap > 0 || bestGap <= maxAlignGap) {
+			assigned[best] = append(assigned[best], text.Content)
+		}
+	}
+
+	aligned := make([]Text, len(base.Texts))
+	for i, text := range base.Texts {
+		aligned[i] = Text{Start: text.Start, Duration: text.Duration, Content: strings.Join(assigned[i], " ")}
+	}
+	return &TranscriptPair{Original: base, Translated: other.withTexts(aligned)}
+}
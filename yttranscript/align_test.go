@@ -0,0 +1,62 @@
+package yttranscript_test
+
+import (
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestAlignTranscripts(t *testing.T) {
+	base := &yttranscript.Transcript{LanguageCode: "en", Texts: []yttranscript.Text{
+		{Start: 0, Duration: 3, Content: "Hello there"},
+		{Start: 3, Duration: 4, Content: "How are you today?"},
+		{Start: 8, Duration: 2, Content: "Fine"},
+		{Start: 20, Duration: 2, Content: "Alone"},
+	}}
+	tests := []struct {
+		name  string
+		other []yttranscript.Text
+		want  []string
+	}{
+		{
+			name: "split differently",
+			other: []yttranscript.Text{
+				{Start: 0.2, Duration: 2, Content: "Hola"},
+				{Start: 2.5, Duration: 2.5, Content: "¿Cómo estás"},
+				{Start: 5.1, Duration: 1.5, Content: "hoy?"},
+				{Start: 10.5, Duration: 1, Content: "Bien"},
+				{Start: 40, Duration: 1, Content: "lost"},
+			},
+			want: []string{"Hola", "¿Cómo estás hoy?", "Bien", ""},
+		},
+		{
+			name:  "blank segments are skipped",
+			other: []yttranscript.Text{{Start: 0, Duration: 3, Content: " "}, {Start: 20, Duration: 2, Content: "Solo"}},
+			want:  []string{"", "", "", "Solo"},
+		},
+		{
+			name: "empty",
+			want: []string{"", "", "", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := &yttranscript.Transcript{LanguageCode: "es", Texts: tt.other}
+			pair := yttranscript.AlignTranscripts(base, other)
+			if pair.Original != base || pair.Translated.LanguageCode != "es" {
+				t.Errorf("pair = %+v", pair)
+			}
+			if len(pair.Translated.Texts) != len(tt.want) {
+				t.Fatalf("got %d segments, want %d", len(pair.Translated.Texts), len(tt.want))
+			}
+			for i, text := range pair.Translated.Texts {
+				if text.Content != tt.want[i] {
+					t.Errorf("segment %d = %q, want %q", i, text.Content, tt.want[i])
+				}
+				if text.Start != base.Texts[i].Start || text.Duration != base.Texts[i].Duration {
+					t.Errorf("segment %d timing = %v+%v, want the base timing", i, text.Start, text.Duration)
+				}
+			}
+		})
+	}
+}
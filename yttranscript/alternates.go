@@ -0,0 +1,66 @@
+package yttranscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// AlternateSources knows other uploads of the same content as a video, such
+// as re-uploads and mirrors, to fetch the transcript from when the video
+// itself is unavailable.
+type AlternateSources interface {
+	// Alternates returns the IDs of duplicates of videoID, best first.
+	Alternates(videoID string) []string
+}
+
+// AlternateMap is a user-provided mapping from video IDs to duplicates.
+type AlternateMap map[string][]string
+
+// Alternates implements AlternateSources.
+func (m AlternateMap) Alternates(videoID string) []string {
+	return m[videoID]
+}
+
+// WithAlternateSources makes GetTranscript and WaitForTranscript retry with
+// the duplicates known to sources when the requested video is unavailable,
+// private or removed. A transcript fetched from a duplicate has the
+// duplicate's VideoID, records the requested video in
+// Provenance.AlternateFor and carries a warning.
+func WithAlternateSources(sources AlternateSources) CallOption {
+	return func(call *callConfig) {
+		call.alternates = sources
+	}
+}
+
+// getAlternateTranscript tries the duplicates of videoID after fetching it
+// failed with err. It returns err unchanged when no duplicate helps.
+func (c *Client) getAlternateTranscript(ctx context.Context, videoID, languageCode string, call callConfig, err error) (*Transcript, error) {
+	if call.alternates == nil || !isUnavailable(err) {
+		return nil, err
+	}
+	alternates := call.alternates.Alternates(videoID)
+	call.alternates = nil // Do not follow duplicates of duplicates.
+	for _, alternate := range alternates {
+		c.logger.Info("video unavailable, trying duplicate", "video_id", videoID, "alternate", alternate)
+		transcript, altErr := c.getTranscript(ctx, alternate, languageCode, call)
+		if altErr != nil {
+			c.logger.Info("duplicate failed", "video_id", videoID, "alternate", alternate, "error", altErr)
+			continue
+		}
+		transcript.Provenance.AlternateFor = videoID
+		transcript.Warnings = append(transcript.Warnings, fmt.Sprintf(
+			"video %s is unavailable; transcript taken from duplicate %s", videoID, alternate))
+		return transcript, nil
+	}
+	return nil, err
+}
+
+// isUnavailable reports whether err means the video itself cannot be had, as
+// opposed to the request being blocked, which a duplicate would not fix.
+func isUnavailable(err error) bool {
+	if errors.Is(err, ErrBotCheck) {
+		return false
+	}
+	return errors.Is(err, ErrVideoUnavailable) || errors.Is(err, ErrUnplayable) || errors.Is(err, ErrLoginRequired)
+}
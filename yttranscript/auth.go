@@ -0,0 +1,27 @@
+package yttranscript
+
+// TokenSource supplies OAuth access tokens for a Google account, such as an
+// oauth.FileTokenSource.
+type TokenSource interface {
+	// Token returns a currently valid access token.
+	Token() (string, error)
+}
+
+// StaticToken is a TokenSource always returning the same access token, for
+// tokens obtained and refreshed elsewhere.
+type StaticToken string
+
+// Token implements TokenSource.
+func (t StaticToken) Token() (string, error) {
+	return string(t), nil
+}
+
+// WithOAuthToken signs player requests in to the account of source, so that
+// members-only videos of channels the account is a member of and the
+// account's own private videos can be fetched. Accounts get flagged for
+// automated use like anonymous clients do, so use one that can be spared.
+func WithOAuthToken(source TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = source
+	}
+}
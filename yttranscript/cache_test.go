@@ -0,0 +1,52 @@
+package yttranscript_test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"yt-transcript/httpcache"
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+// countingTransport counts the requests passed to base.
+type countingTransport struct {
+	base     http.RoundTripper
+	requests atomic.Int32
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests.Add(1)
+	return t.base.RoundTrip(req)
+}
+
+func TestCacheSecondRun(t *testing.T) {
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	dir := t.TempDir()
+
+	for run := range 2 {
+		cache, err := httpcache.New(dir, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache.TTL = time.Hour
+		transport := &countingTransport{base: s.Transport()}
+		client, err := yttranscript.New(yttranscript.WithTransport(transport), yttranscript.WithCache(cache))
+		if err != nil {
+			t.Fatal(err)
+		}
+		transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en")
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+		if len(transcript.Texts) == 0 {
+			t.Errorf("run %d: empty transcript", run)
+		}
+		if got := transport.requests.Load(); run == 0 && got == 0 || run == 1 && got != 0 {
+			t.Errorf("run %d made %d requests", run, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package yttranscript
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Chapter is a titled section of a video.
+type Chapter struct {
+	Start float64 // Start time in seconds.
+	Title string
+}
+
+// chapterLineRegex matches description lines such as "1:23 Title" or
+// "01:02:03 - Title".
+var chapterLineRegex = regexp.MustCompile(`^\s*\(?((?:\d{1,2}:)?\d{1,2}:\d{2})\)?\s*[-–—:|]?\s*(.+?)\s*$`)
+
+// ParseChapters extracts chapters from a video description the same way
+// YouTube does: a list of timestamped lines starting at 0:00. It returns nil
+// if the description does not contain such a list.
+func ParseChapters(description string) []Chapter {
+	var chapters []Chapter
+	for _, line := range strings.Split(description, "\n") {
+		matches := chapterLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		start, ok := parseClock(matches[1])
+		if !ok {
+			continue
+		}
+		if len(chapters) > 0 && start <= chapters[len(chapters)-1].Start {
+			continue
+		}
+		chapters = append(chapters, Chapter{Start: start, Title: matches[2]})
+	}
+
+	if len(chapters) < 2 || chapters[0].Start != 0 {
+		return nil
+	}
+	return chapters
+}
+
+// parseClock converts "h:mm:ss" or "m:ss" into seconds.
+func parseClock(clock string) (float64, bool) {
+	total := 0
+	for _, part := range strings.Split(clock, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, false
+		}
+		total = total*60 + n
+	}
+	return float64(total), true
+}
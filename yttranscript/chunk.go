@@ -0,0 +1,105 @@
+package yttranscript
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Merge combines consecutive segments into larger ones of at most maxChars
+// characters, joined by spaces, with start and duration recomputed to span
+// the merged segments. A single segment longer than maxChars is kept as is.
+func (t *Transcript) Merge(maxChars int) *Transcript {
+	return t.MergeBy(maxChars, utf8.RuneCountInString)
+}
+
+// MergeBy is like Merge but measures text with size, for example a
+// tokenizer's Count method to build chunks that fit a token budget.
+func (t *Transcript) MergeBy(limit int, size func(string) int) *Transcript {
+	var merged []Text
+	var current []Text
+	flush := func() {
+		if len(current) > 0 {
+			merged = append(merged, joinTexts(current))
+			current = nil
+		}
+	}
+
+	for _, text := range t.Texts {
+		if len(current) > 0 {
+			candidate := joinTexts(append(current[:len(current):len(current)], text))
+			if size(candidate.Content) > limit {
+				flush()
+			}
+		}
+		current = append(current, text)
+	}
+	flush()
+
+	return t.withTexts(merged)
+}
+
+// Rechunk groups segments into chunks covering window of time each, with
+// consecutive chunks overlapping by overlap so that context is not lost at
+// chunk boundaries. A segment belongs to every chunk its start time falls
+// into. An overlap not shorter than window is treated as no overlap.
+func (t *Transcript) Rechunk(window, overlap time.Duration) *Transcript {
+	if window <= 0 || len(t.Texts) == 0 {
+		return t.withTexts(append([]Text(nil), t.Texts...))
+	}
+	if overlap < 0 || overlap >= window {
+		overlap = 0
+	}
+	windowSeconds := window.Seconds()
+	step := (window - overlap).Seconds()
+
+	texts := append([]Text(nil), t.Texts...)
+	sort.SliceStable(texts, func(i, j int) bool { return texts[i].Start < texts[j].Start })
+
+	// Sweep the windows with texts[lo:hi] holding the segments starting in
+	// the current one, skipping ahead over windows a gap leaves empty.
+	var chunks []Text
+	first := texts[0].Start
+	lo, hi := 0, 0
+	for k := 0; lo < len(texts); k++ {
+		from := first + float64(k)*step
+		for lo < len(texts) && texts[lo].Start < from {
+			lo++
+		}
+		for hi < len(texts) && texts[hi].Start < from+windowSeconds {
+			hi++
+		}
+		if lo < hi {
+			chunks = append(chunks, joinTexts(texts[lo:hi]))
+		} else if lo < len(texts) {
+			k = max(k, int((texts[lo].Start-windowSeconds-first)/step))
+		}
+	}
+
+	return t.withTexts(chunks)
+}
+
+// joinTexts merges segments into one spanning all of them.
+func joinTexts(texts []Text) Text {
+	parts := make([]string, 0, len(texts))
+	end := texts[0].End()
+	for _, text := range texts {
+		if text.Content != "" {
+			parts = append(parts, text.Content)
+		}
+		end = max(end, text.End())
+	}
+	return Text{
+		Start:    texts[0].Start,
+		Duration: end - texts[0].Start,
+		Content:  strings.Join(parts, " "),
+	}
+}
+
+// withTexts returns a copy of the transcript with its segments replaced.
+func (t *Transcript) withTexts(texts []Text) *Transcript {
+	out := *t
+	out.Texts = texts
+	return &out
+}
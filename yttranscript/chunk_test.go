@@ -0,0 +1,140 @@
+package yttranscript_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+var chunkTexts = []yttranscript.Text{
+	{Start: 0, Duration: 1, Content: "one"},
+	{Start: 1, Duration: 1, Content: "two"},
+	{Start: 2, Duration: 1, Content: "three"},
+	{Start: 3, Duration: 2, Content: "a much longer line"},
+}
+
+func TestMerge(t *testing.T) {
+	words := func(s string) int { return len(strings.Fields(s)) }
+	tests := []struct {
+		name  string
+		merge func(*yttranscript.Transcript) *yttranscript.Transcript
+		want  []yttranscript.Text
+	}{
+		{
+			name:  "by characters",
+			merge: func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Merge(7) },
+			want: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "one two"},
+				{Start: 2, Duration: 1, Content: "three"},
+				{Start: 3, Duration: 2, Content: "a much longer line"},
+			},
+		},
+		{
+			name:  "everything fits",
+			merge: func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Merge(100) },
+			want:  []yttranscript.Text{{Start: 0, Duration: 5, Content: "one two three a much longer line"}},
+		},
+		{
+			name:  "nothing fits",
+			merge: func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Merge(0) },
+			want:  chunkTexts,
+		},
+		{
+			name:  "by words",
+			merge: func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.MergeBy(3, words) },
+			want: []yttranscript.Text{
+				{Start: 0, Duration: 3, Content: "one two three"},
+				{Start: 3, Duration: 2, Content: "a much longer line"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.merge(&yttranscript.Transcript{Texts: chunkTexts}).Texts
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRechunk(t *testing.T) {
+	tests := []struct {
+		name            string
+		texts           []yttranscript.Text
+		window, overlap time.Duration
+		want            []yttranscript.Text
+	}{
+		{
+			name:   "no overlap",
+			texts:  chunkTexts,
+			window: 2 * time.Second,
+			want: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "one two"},
+				{Start: 2, Duration: 3, Content: "three a much longer line"},
+			},
+		},
+		{
+			name:    "overlap",
+			texts:   chunkTexts,
+			window:  2 * time.Second,
+			overlap: time.Second,
+			want: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "one two"},
+				{Start: 1, Duration: 2, Content: "two three"},
+				{Start: 2, Duration: 3, Content: "three a much longer line"},
+				{Start: 3, Duration: 2, Content: "a much longer line"},
+			},
+		},
+		{
+			name:    "overlap not shorter than window is ignored",
+			texts:   chunkTexts,
+			window:  2 * time.Second,
+			overlap: 5 * time.Second,
+			want: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "one two"},
+				{Start: 2, Duration: 3, Content: "three a much longer line"},
+			},
+		},
+		{
+			name:   "gap skips empty windows",
+			texts:  []yttranscript.Text{{Start: 0, Duration: 1, Content: "a"}, {Start: 10, Duration: 1, Content: "b"}},
+			window: 2 * time.Second,
+			want:   []yttranscript.Text{{Start: 0, Duration: 1, Content: "a"}, {Start: 10, Duration: 1, Content: "b"}},
+		},
+		{
+			name:   "tiny window over a long gap",
+			texts:  []yttranscript.Text{{Start: 0, Duration: 1, Content: "a"}, {Start: 1e6, Duration: 1, Content: "b"}, {Start: 1e6 + 1, Duration: 1, Content: "c"}},
+			window: time.Nanosecond,
+			want:   []yttranscript.Text{{Start: 0, Duration: 1, Content: "a"}, {Start: 1e6, Duration: 1, Content: "b"}, {Start: 1e6 + 1, Duration: 1, Content: "c"}},
+		},
+		{
+			name:   "unsorted",
+			texts:  []yttranscript.Text{{Start: 2, Duration: 1, Content: "b"}, {Start: 0, Duration: 1, Content: "a"}},
+			window: 5 * time.Second,
+			want:   []yttranscript.Text{{Start: 0, Duration: 3, Content: "a b"}},
+		},
+		{
+			name:  "no window is a copy",
+			texts: chunkTexts,
+			want:  chunkTexts,
+		},
+		{
+			name:   "empty",
+			window: time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&yttranscript.Transcript{Texts: tt.texts}).Rechunk(tt.window, tt.overlap).Texts
+			if len(got) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package yttranscript
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// MarkupMode selects what is done with the HTML entities and markup tags in
+// fetched caption text.
+type MarkupMode int
+
+const (
+	// MarkupDecodeAndStrip decodes entities and strips tags. It is the
+	// default.
+	MarkupDecodeAndStrip MarkupMode = iota
+	// MarkupDecodeOnly decodes entities and keeps every tag.
+	MarkupDecodeOnly
+	// MarkupStripOnly strips tags and leaves entities such as "&amp;" as
+	// they are.
+	MarkupStripOnly
+)
+
+// CleanOptions selects optional cleaning steps for transcript text.
+type CleanOptions struct {
+	// Markup selects whether entities are decoded and tags stripped. Only
+	// the tags used in captions, such as <i>, <font> and WebVTT's <c> and
+	// <v>, count as tags, so text such as "<3" or "<door slams>" is kept.
+	Markup MarkupMode
+	// PreserveFormatting keeps formatting tags such as <i> and <b> and the
+	// whitespace around text, which are otherwise stripped. Other tags are
+	// still removed unless Markup is MarkupDecodeOnly.
+	PreserveFormatting bool
+	// RemoveSoundDescriptions strips annotations such as "[Music]",
+	// "[Applause]", "(laughs)" and "♪" music notes. Parenthesized
+	// annotations are recognized by the sound words of the transcript
+	// language's locale pack.
+	RemoveSoundDescriptions bool
+	// RemoveSpeakerLabels strips leading speaker markers such as ">>" and
+	// "JOHN:", with the markers taken from the locale pack.
+	RemoveSpeakerLabels bool
+	// RemoveFillers strips hesitation words such as "um" and "uh", as
+	// listed by the locale pack.
+	RemoveFillers bool
+	// CollapseWhitespace replaces runs of whitespace, including line breaks
+	// within a segment, with single spaces.
+	CollapseWhitespace bool
+	// LocalePacks replace the built-in locale packs for their languages or
+	// add packs for languages without one. Languages with neither use the
+	// English pack.
+	LocalePacks []*LocalePack
+}
+
+var (
+	htmlTagRegex           = regexp.MustCompile(`(?i)</?(?:(?:b|i|u|s|em|strong|mark|small|del|ins|sub|sup|br|ruby|rt|rp)\s*/?|(?:font|span|p|div)(?:\s+[a-z-]+(?:=(?:"[^"]*"|'[^']*'|[^\s<>]+))?)*\s*/?|(?:c|v|lang)(?:\.[\w.-]+)*(?:\s[^<>]*)?)>`)
+	formattingTagRegex     = regexp.MustCompile(`(?i)^</?(?:b|i|u|s|em|strong|mark|small|del|ins|sub|sup)\b[^>]*>$`)
+	bracketAnnotationRegex = regexp.MustCompile(`\[[^\]]*\]`)
+	musicNoteRegex         = regexp.MustCompile(`[♪♫]+`)
+)
+
+// cleanTranscript decodes entities and strips markup from every segment as
+// selected by opts.Markup, the cleaning always performed on fetched text.
+func cleanTranscript(transcript *Transcript, opts CleanOptions) {
+	for i := range transcript.Texts {
+		cleanText := transcript.Texts[i].Content
+		if opts.Markup != MarkupStripOnly {
+			cleanText = html.UnescapeString(cleanText)
+		}
+		if opts.Markup == MarkupDecodeOnly {
+			if !opts.PreserveFormatting {
+				cleanText = strings.TrimSpace(cleanText)
+			}
+			transcript.Texts[i].Content = cleanText
+			continue
+		}
+		if opts.PreserveFormatting {
+			cleanText = htmlTagRegex.ReplaceAllStringFunc(cleanText, func(tag string) string {
+				if formattingTagRegex.MatchString(tag) {
+					return tag
+				}
+				return ""
+			})
+			transcript.Texts[i].Content = cleanText
+			continue
+		}
+		cleanText = htmlTagRegex.ReplaceAllString(cleanText, "")
+		transcript.Texts[i].Content = strings.TrimSpace(cleanText)
+	}
+}
+
+// applyCleanOptions runs the selected cleaning steps over every segment and
+// drops segments left without text.
+func applyCleanOptions(transcript *Transcript, opts CleanOptions) {
+	if !opts.RemoveSoundDescriptions && !opts.RemoveSpeakerLabels && !opts.RemoveFillers && !opts.CollapseWhitespace {
+		return
+	}
+
+	pack := localePack(transcript.LanguageCode, opts.LocalePacks)
+
+	texts := transcript.Texts[:0]
+	for _, text := range transcript.Texts {
+		content := text.Content
+		if opts.RemoveSoundDescriptions {
+			content = bracketAnnotationRegex.ReplaceAllString(content, "")
+			content = pack.removeSoundDescriptions(content)
+			content = musicNoteRegex.ReplaceAllString(content, "")
+		}
+		if opts.RemoveSpeakerLabels {
+			content = pack.removeSpeakerLabels(content)
+		}
+		if opts.RemoveFillers {
+			content = pack.removeFillers(content)
+		}
+		if opts.CollapseWhitespace {
+			content = strings.Join(strings.Fields(content), " ")
+		}
+		if !opts.PreserveFormatting {
+			content = strings.TrimSpace(content)
+		}
+		text.Content = content
+		if strings.TrimSpace(text.Content) != "" {
+			texts = append(texts, text)
+		}
+	}
+	transcript.Texts = texts
+}
@@ -0,0 +1,113 @@
+package yttranscript_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+// fetchCleaned serves segments as the fixture video's English track and
+// returns the contents GetTranscript produces with the given options.
+func fetchCleaned(t *testing.T, segments []string, opts ...yttranscript.CallOption) []string {
+	t.Helper()
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	var xml strings.Builder
+	xml.WriteString("<transcript>")
+	for i, segment := range segments {
+		fmt.Fprintf(&xml, `<text start="%d" dur="1">%s</text>`, i, segment)
+	}
+	xml.WriteString("</transcript>")
+	s.SetTimedText(yttranscripttest.FixtureVideoID, "en", []byte(xml.String()))
+
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en", opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var contents []string
+	for _, text := range transcript.Texts {
+		contents = append(contents, text.Content)
+	}
+	return contents
+}
+
+func TestCleanOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		segments []string
+		opts     yttranscript.CleanOptions
+		want     []string
+	}{
+		{
+			name:     "entities and tags always handled",
+			segments: []string{"rock &amp;amp; roll", "&lt;i&gt;quiet&lt;/i&gt; please"},
+			want:     []string{"rock & roll", "quiet please"},
+		},
+		{
+			name:     "only caption tags are markup",
+			segments: []string{"I &lt;3 you &lt;door slams&gt;", `&lt;font color="#fff"&gt;hey&lt;/font&gt; &lt;c.yellow&gt;you&lt;/c&gt;`},
+			want:     []string{"I <3 you <door slams>", "hey you"},
+		},
+		{
+			name:     "decode only",
+			segments: []string{"&lt;i&gt;rock&lt;/i&gt; &amp;amp; roll"},
+			opts:     yttranscript.CleanOptions{Markup: yttranscript.MarkupDecodeOnly},
+			want:     []string{"<i>rock</i> & roll"},
+		},
+		{
+			name:     "strip only",
+			segments: []string{"&lt;i&gt;rock&lt;/i&gt; &amp;amp; roll"},
+			opts:     yttranscript.CleanOptions{Markup: yttranscript.MarkupStripOnly},
+			want:     []string{"rock &amp; roll"},
+		},
+		{
+			name:     "preserve formatting",
+			segments: []string{"&lt;i&gt;quiet&lt;/i&gt; &lt;font color=&quot;red&quot;&gt;please&lt;/font&gt; ", "&lt;B&gt;loud&lt;/B&gt;"},
+			opts:     yttranscript.CleanOptions{PreserveFormatting: true},
+			want:     []string{"<i>quiet</i> please ", "<B>loud</B>"},
+		},
+		{
+			name:     "preserve formatting with collapsed whitespace",
+			segments: []string{"&lt;b&gt;a&lt;/b&gt;\n  b"},
+			opts:     yttranscript.CleanOptions{PreserveFormatting: true, CollapseWhitespace: true},
+			want:     []string{"<b>a</b> b"},
+		},
+		{
+			name:     "sound descriptions",
+			segments: []string{"[Music]", "♪ la la ♪", "that was funny (laughs)", "(Applause) thank you", "keep (this) aside"},
+			opts:     yttranscript.CleanOptions{RemoveSoundDescriptions: true},
+			want:     []string{"la la", "that was funny", "thank you", "keep (this) aside"},
+		},
+		{
+			name:     "speaker labels",
+			segments: []string{"&gt;&gt; Hello", "JOHN: hi there", "&gt;&gt; MARY SMITH: yes", "Time: noon"},
+			opts:     yttranscript.CleanOptions{RemoveSpeakerLabels: true},
+			want:     []string{"Hello", "hi there", "yes", "Time: noon"},
+		},
+		{
+			name:     "collapse whitespace",
+			segments: []string{"one\ntwo   three", " four "},
+			opts:     yttranscript.CleanOptions{CollapseWhitespace: true},
+			want:     []string{"one two three", "four"},
+		},
+		{
+			name:     "combined",
+			segments: []string{"&gt;&gt; BOB: [Music] well\nthen", "[Applause]"},
+			opts:     yttranscript.CleanOptions{RemoveSoundDescriptions: true, RemoveSpeakerLabels: true, CollapseWhitespace: true},
+			want:     []string{"well then"},
+		},
+	}
+	for _, tt := range tests {
+		got := fetchCleaned(t, tt.segments, yttranscript.WithCleanOptions(tt.opts))
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package yttranscript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// captionTrackPaths lists the places in a player response where caption
+// tracks have been seen, most common first.
+var captionTrackPaths = [][]string{
+	{"captions", "playerCaptionsTracklistRenderer", "captionTracks"},
+	{"playerCaptionsTracklistRenderer", "captionTracks"},
+	{"captions", "playerCaptionsRenderer", "captionTracks"},
+}
+
+// decodePlayerResponse decodes a player response without failing on parts it
+// does not understand. Fields whose type changed upstream are skipped rather
+// than aborting the decode, and when caption tracks are not at their usual
+// location the other known locations are tried, then the whole document is
+// searched for a captionTracks array. The location used is recorded in
+// CaptionTracksPath.
+func decodePlayerResponse(data []byte) (*PlayerResponse, error) {
+	var playerResponse PlayerResponse
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(data, &playerResponse); err != nil && !errors.As(err, &typeErr) {
+		return nil, err
+	}
+
+	if len(playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks) > 0 {
+		playerResponse.CaptionTracksPath = strings.Join(captionTrackPaths[0], ".")
+		return &playerResponse, nil
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	value, path := lookupPaths(doc, captionTrackPaths[1:])
+	if value == nil {
+		value, path = searchKey(doc, "captionTracks", nil)
+	}
+	if value == nil {
+		return &playerResponse, nil
+	}
+
+	tracks, err := decodeCaptionTracks(value)
+	if err != nil {
+		return &playerResponse, nil
+	}
+	playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks = tracks
+	playerResponse.CaptionTracksPath = strings.Join(path, ".")
+	return &playerResponse, nil
+}
+
+func lookupPaths(doc interface{}, paths [][]string) (interface{}, []string) {
+	for _, path := range paths {
+		value := doc
+		for _, key := range path {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = object[key]
+		}
+		if _, ok := value.([]interface{}); ok {
+			return value, path
+		}
+	}
+	return nil, nil
+}
+
+// searchKey walks the document depth-first, visiting object keys in sorted
+// order, and returns the first array stored under key.
+func searchKey(value interface{}, key string, path []string) (interface{}, []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if found, ok := v[key].([]interface{}); ok {
+			return found, append(path, key)
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if found, foundPath := searchKey(v[k], key, append(path[:len(path):len(path)], k)); found != nil {
+				return found, foundPath
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if found, foundPath := searchKey(item, key, append(path[:len(path):len(path)], fmt.Sprint(i))); found != nil {
+				return found, foundPath
+			}
+		}
+	}
+	return nil, nil
+}
+
+func decodeCaptionTracks(value interface{}) ([]CaptionTrack, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var tracks []CaptionTrack
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(data, &tracks); err != nil && !errors.As(err, &typeErr) {
+		return nil, err
+	}
+	return tracks, nil
+}
@@ -0,0 +1,75 @@
+package yttranscript
+
+import "testing"
+
+func TestDecodePlayerResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantPath string
+		wantLang []string
+	}{
+		{
+			name:     "usual location",
+			data:     `{"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[{"baseUrl":"u","languageCode":"en"}]}}}`,
+			wantPath: "captions.playerCaptionsTracklistRenderer.captionTracks",
+			wantLang: []string{"en"},
+		},
+		{
+			name:     "renderer at top level",
+			data:     `{"playerCaptionsTracklistRenderer":{"captionTracks":[{"languageCode":"de"},{"languageCode":"fr"}]}}`,
+			wantPath: "playerCaptionsTracklistRenderer.captionTracks",
+			wantLang: []string{"de", "fr"},
+		},
+		{
+			name:     "older renderer name",
+			data:     `{"captions":{"playerCaptionsRenderer":{"captionTracks":[{"languageCode":"es"}]}}}`,
+			wantPath: "captions.playerCaptionsRenderer.captionTracks",
+			wantLang: []string{"es"},
+		},
+		{
+			name:     "searched in sorted key order",
+			data:     `{"z":{"captionTracks":[{"languageCode":"zz"}]},"a":[{"b":{"captionTracks":[{"languageCode":"ja"}]}}]}`,
+			wantPath: "a.0.b.captionTracks",
+			wantLang: []string{"ja"},
+		},
+		{
+			name:     "changed field type is skipped",
+			data:     `{"videoDetails":{"lengthSeconds":212},"captions":{"playerCaptionsTracklistRenderer":{"captionTracks":[{"languageCode":"en","isTranslatable":"yes"}]}}}`,
+			wantPath: "captions.playerCaptionsTracklistRenderer.captionTracks",
+			wantLang: []string{"en"},
+		},
+		{
+			name: "no captions",
+			data: `{"playabilityStatus":{"status":"OK"}}`,
+		},
+		{
+			name: "captionTracks is not an array",
+			data: `{"captions":{"captionTracks":"none"}}`,
+		},
+	}
+	for _, tt := range tests {
+		playerResponse, err := decodePlayerResponse([]byte(tt.data))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if playerResponse.CaptionTracksPath != tt.wantPath {
+			t.Errorf("%s: CaptionTracksPath = %q, want %q", tt.name, playerResponse.CaptionTracksPath, tt.wantPath)
+		}
+		tracks := playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+		if len(tracks) != len(tt.wantLang) {
+			t.Errorf("%s: %d tracks, want %d", tt.name, len(tracks), len(tt.wantLang))
+			continue
+		}
+		for i, track := range tracks {
+			if track.LanguageCode != tt.wantLang[i] {
+				t.Errorf("%s: track %d language = %q, want %q", tt.name, i, track.LanguageCode, tt.wantLang[i])
+			}
+		}
+	}
+
+	if _, err := decodePlayerResponse([]byte(`{"captions":`)); err == nil {
+		t.Error("truncated JSON decoded without error")
+	}
+}
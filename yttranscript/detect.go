@@ -0,0 +1,212 @@
+package yttranscript
+
+import (
+	"embed"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// langData holds sample text per language from which the trigram profiles
+// used by DetectLanguage are built.
+//
+//go:embed langdata/*.txt
+var langData embed.FS
+
+const (
+	// profileSize is how many of the most frequent trigrams make up a
+	// language profile.
+	profileSize = 300
+	// minDetectLetters is the least amount of text DetectLanguage will
+	// classify; shorter transcripts are too ambiguous.
+	minDetectLetters = 100
+	// minDetectConfidence is the confidence below which a detected language
+	// is not trusted enough to report a mismatch.
+	minDetectConfidence = 0.1
+)
+
+// scriptLanguages maps scripts used by a single common language to it.
+var scriptLanguages = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Thai, "th"},
+}
+
+var (
+	profilesOnce sync.Once
+	profiles     map[string]map[string]int // language -> trigram -> rank
+)
+
+func languageProfiles() map[string]map[string]int {
+	profilesOnce.Do(func() {
+		profiles = make(map[string]map[string]int)
+		entries, _ := langData.ReadDir("langdata")
+		for _, entry := range entries {
+			data, err := langData.ReadFile(path.Join("langdata", entry.Name()))
+			if err != nil {
+				continue
+			}
+			profiles[strings.TrimSuffix(entry.Name(), ".txt")] = rankTrigrams(string(data))
+		}
+	})
+	return profiles
+}
+
+// DetectLanguage guesses the language of the transcript text. It returns the
+// ISO 639-1 code of the most likely language together with a confidence
+// between 0 and 1, or an empty code when there is too little text or it
+// matches none of the known languages.
+//
+// Languages with their own script (Japanese, Korean, Chinese, Arabic, ...)
+// are recognised by script; Latin and Cyrillic text is compared against
+// character trigram profiles of the languages in langdata.
+func (t *Transcript) DetectLanguage() (string, float64) {
+	var text strings.Builder
+	for _, segment := range t.Texts {
+		text.WriteString(segment.Content)
+		text.WriteByte(' ')
+	}
+	return detectLanguage(text.String())
+}
+
+func detectLanguage(text string) (string, float64) {
+	scriptCounts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, script := range scriptLanguages {
+			if unicode.Is(script.table, r) {
+				scriptCounts[script.language]++
+				break
+			}
+		}
+	}
+	if letters < minDetectLetters {
+		return "", 0
+	}
+
+	// Japanese mixes kana with Han characters, so any noticeable share of
+	// kana decides it before Han is considered.
+	if kana := scriptCounts["ja"]; kana*10 >= letters {
+		return "ja", float64(kana+scriptCounts["zh"]) / float64(letters)
+	}
+	for language, count := range scriptCounts {
+		if count*2 >= letters {
+			return language, float64(count) / float64(letters)
+		}
+	}
+
+	observed := rankTrigrams(text)
+	type candidate struct {
+		language string
+		distance int
+	}
+	var candidates []candidate
+	for language, profile := range languageProfiles() {
+		candidates = append(candidates, candidate{language, outOfPlace(observed, profile)})
+	}
+	if len(candidates) < 2 {
+		return "", 0
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].distance < candidates[j].distance })
+
+	best, second := candidates[0], candidates[1]
+	confidence := float64(second.distance-best.distance) / float64(second.distance)
+	return best.language, confidence
+}
+
+// rankTrigrams returns the profileSize most frequent character trigrams of
+// text, with words padded by spaces, mapped to their rank.
+func rankTrigrams(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	trigrams := make([]string, 0, len(counts))
+	for trigram := range counts {
+		trigrams = append(trigrams, trigram)
+	}
+	sort.Slice(trigrams, func(i, j int) bool {
+		if counts[trigrams[i]] != counts[trigrams[j]] {
+			return counts[trigrams[i]] > counts[trigrams[j]]
+		}
+		return trigrams[i] < trigrams[j]
+	})
+	if len(trigrams) > profileSize {
+		trigrams = trigrams[:profileSize]
+	}
+
+	ranks := make(map[string]int, len(trigrams))
+	for i, trigram := range trigrams {
+		ranks[trigram] = i
+	}
+	return ranks
+}
+
+// outOfPlace is the Cavnar-Trenkle distance between two trigram rankings:
+// the sum of rank differences, with trigrams missing from profile counting
+// as the maximum.
+func outOfPlace(observed, profile map[string]int) int {
+	distance := 0
+	for trigram, rank := range observed {
+		if profileRank, ok := profile[trigram]; ok {
+			distance += abs(rank - profileRank)
+		} else {
+			distance += profileSize
+		}
+	}
+	return distance
+}
+
+// canDetectLanguage reports whether DetectLanguage can recognise the base
+// language of tag.
+func canDetectLanguage(tag string) bool {
+	base := parseLanguageTag(tag).base
+	if _, ok := languageProfiles()[base]; ok {
+		return true
+	}
+	for _, script := range scriptLanguages {
+		if script.language == base {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLanguage compares the detected language of the transcript with its
+// label. A confident mismatch is returned as a *LanguageMismatchError when
+// strict is set and recorded as a warning otherwise.
+func checkLanguage(transcript *Transcript, strict bool) error {
+	if !canDetectLanguage(transcript.LanguageCode) {
+		return nil
+	}
+	detected, confidence := transcript.DetectLanguage()
+	if detected == "" || confidence < minDetectConfidence || detected == parseLanguageTag(transcript.LanguageCode).base {
+		return nil
+	}
+
+	mismatch := &LanguageMismatchError{Labeled: transcript.LanguageCode, Detected: detected, Confidence: confidence}
+	if strict {
+		return mismatch
+	}
+	transcript.Warnings = append(transcript.Warnings, mismatch.Error())
+	return nil
+}
@@ -0,0 +1,74 @@
+package yttranscript_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+var detectSamples = map[string]string{
+	"en": "We are going to talk about the history of the city and how the people who lived there built their houses, their roads and their markets over the years.",
+	"de": "Wir sprechen heute über die Geschichte der Stadt und darüber, wie die Menschen, die dort lebten, ihre Häuser, Straßen und Märkte im Laufe der Jahre gebaut haben.",
+	"es": "Hoy vamos a hablar de la historia de la ciudad y de cómo las personas que vivían allí construyeron sus casas, sus calles y sus mercados a lo largo de los años.",
+	"fr": "Aujourd'hui nous allons parler de l'histoire de la ville et de la façon dont les gens qui y vivaient ont construit leurs maisons, leurs rues et leurs marchés au fil des années.",
+	"ru": "Сегодня мы поговорим об истории города и о том, как люди, которые там жили, строили свои дома, улицы и рынки на протяжении многих лет.",
+	"ja": "今日はこの町の歴史について話します。そこに住んでいた人々が、長い年月をかけてどのように家や道や市場を作ってきたのかを見ていきましょう。それはとても興味深い話です。",
+}
+
+func TestDetectLanguage(t *testing.T) {
+	for want, sample := range detectSamples {
+		transcript := &yttranscript.Transcript{Texts: []yttranscript.Text{{Content: sample}, {Content: sample}}}
+		got, confidence := transcript.DetectLanguage()
+		if got != want || confidence <= 0 || confidence > 1 {
+			t.Errorf("DetectLanguage(%s sample) = %q, %v", want, got, confidence)
+		}
+	}
+
+	short := &yttranscript.Transcript{Texts: []yttranscript.Text{{Content: "hello there"}}}
+	if got, _ := short.DetectLanguage(); got != "" {
+		t.Errorf("DetectLanguage(short) = %q, want empty", got)
+	}
+}
+
+func TestLanguageCheck(t *testing.T) {
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en", yttranscript.WithLanguageCheck())
+	if err != nil {
+		t.Fatalf("correctly labeled track: %v", err)
+	}
+	if len(transcript.Warnings) != 0 {
+		t.Errorf("correctly labeled track warned: %q", transcript.Warnings)
+	}
+
+	var xml strings.Builder
+	xml.WriteString("<transcript>")
+	for i := 0; i < 3; i++ {
+		fmt.Fprintf(&xml, `<text start="%d" dur="1">%s</text>`, i, detectSamples["de"])
+	}
+	xml.WriteString("</transcript>")
+	s.SetTimedText(yttranscripttest.FixtureVideoID, "en", []byte(xml.String()))
+
+	transcript, err = client.GetTranscript(yttranscripttest.FixtureVideoID, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript.Warnings) != 1 || !strings.Contains(transcript.Warnings[0], "'de'") {
+		t.Errorf("mislabeled track warnings = %q, want one naming de", transcript.Warnings)
+	}
+
+	_, err = client.GetTranscript(yttranscripttest.FixtureVideoID, "en", yttranscript.WithLanguageCheck())
+	var mismatch *yttranscript.LanguageMismatchError
+	if !errors.Is(err, yttranscript.ErrLanguageMismatch) || !errors.As(err, &mismatch) || mismatch.Detected != "de" || mismatch.Labeled != "en" {
+		t.Errorf("strict check error = %v, want mismatch detecting de", err)
+	}
+}
@@ -0,0 +1,94 @@
+package yttranscript
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// ChangeKind classifies a difference between two transcripts.
+type ChangeKind string
+
+// Kinds of Change.
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a stretch of time where two transcripts say different things.
+type Change struct {
+	Kind   ChangeKind
+	Start  float64
+	End    float64
+	Before string // Text in the first transcript, empty for ChangeAdded.
+	After  string // Text in the second transcript, empty for ChangeRemoved.
+}
+
+// Diff compares two transcripts of the same video, such as ASR and manual
+// captions or two fetches of one track. Each segment of b is aligned to the
+// segment of a whose time span contains its midpoint; segments of b that fall
+// outside every segment of a are reported as added, segments of a with nothing
+// aligned as removed, and aligned groups whose text differs, ignoring case,
+// punctuation and spacing, as modified. Changes are ordered by start time.
+func Diff(a, b *Transcript) []Change {
+	aligned := make([][]Text, len(a.Texts))
+	var changes []Change
+
+	for _, text := range b.Texts {
+		i := containingSegment(a.Texts, text.Start+text.Duration/2)
+		if i < 0 {
+			changes = append(changes, Change{Kind: ChangeAdded, Start: text.Start, End: text.End(), After: text.Content})
+			continue
+		}
+		aligned[i] = append(aligned[i], text)
+	}
+
+	for i, text := range a.Texts {
+		group := aligned[i]
+		if len(group) == 0 {
+			changes = append(changes, Change{Kind: ChangeRemoved, Start: text.Start, End: text.End(), Before: text.Content})
+			continue
+		}
+
+		parts := make([]string, len(group))
+		for j, other := range group {
+			parts[j] = other.Content
+		}
+		after := strings.Join(parts, " ")
+		if normalizeForDiff(text.Content) == normalizeForDiff(after) {
+			continue
+		}
+		changes = append(changes, Change{
+			Kind:   ChangeModified,
+			Start:  min(text.Start, group[0].Start),
+			End:    max(text.End(), group[len(group)-1].End()),
+			Before: text.Content,
+			After:  after,
+		})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Start < changes[j].Start
+	})
+	return changes
+}
+
+// containingSegment returns the index of the latest-starting segment whose
+// span contains t, or -1.
+func containingSegment(texts []Text, t float64) int {
+	i := sort.Search(len(texts), func(i int) bool { return texts[i].Start > t }) - 1
+	for ; i >= 0; i-- {
+		if t < texts[i].End() {
+			return i
+		}
+	}
+	return -1
+}
+
+func normalizeForDiff(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,80 @@
+package yttranscript_test
+
+import (
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestDiff(t *testing.T) {
+	a := &yttranscript.Transcript{Texts: []yttranscript.Text{
+		{Start: 0, Duration: 2, Content: "Hello world"},
+		{Start: 2, Duration: 2, Content: "how are you"},
+		{Start: 4, Duration: 2, Content: "fine thanks"},
+		{Start: 10, Duration: 1, Content: "bye"},
+	}}
+	tests := []struct {
+		name string
+		b    []yttranscript.Text
+		want []yttranscript.Change
+	}{
+		{
+			name: "identical",
+			b:    a.Texts,
+		},
+		{
+			name: "case and punctuation ignored",
+			b: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "hello, world!"},
+				{Start: 2, Duration: 2, Content: "How  are you?"},
+				{Start: 4, Duration: 2, Content: "Fine, thanks."},
+				{Start: 10, Duration: 1, Content: "Bye."},
+			},
+		},
+		{
+			name: "modified, removed and added",
+			b: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "hello, world!"},
+				{Start: 2, Duration: 1, Content: "how are"},
+				{Start: 3, Duration: 1, Content: "they"},
+				{Start: 7, Duration: 1, Content: "extra"},
+				{Start: 10, Duration: 1, Content: "bye"},
+			},
+			want: []yttranscript.Change{
+				{Kind: yttranscript.ChangeModified, Start: 2, End: 4, Before: "how are you", After: "how are they"},
+				{Kind: yttranscript.ChangeRemoved, Start: 4, End: 6, Before: "fine thanks"},
+				{Kind: yttranscript.ChangeAdded, Start: 7, End: 8, After: "extra"},
+			},
+		},
+		{
+			name: "aligned by midpoint",
+			b: []yttranscript.Text{
+				{Start: 0, Duration: 2, Content: "hello world"},
+				{Start: 1.5, Duration: 3, Content: "how are you"},
+				{Start: 4, Duration: 2, Content: "fine thanks"},
+				{Start: 10, Duration: 1, Content: "bye"},
+			},
+		},
+		{
+			name: "empty second transcript",
+			want: []yttranscript.Change{
+				{Kind: yttranscript.ChangeRemoved, Start: 0, End: 2, Before: "Hello world"},
+				{Kind: yttranscript.ChangeRemoved, Start: 2, End: 4, Before: "how are you"},
+				{Kind: yttranscript.ChangeRemoved, Start: 4, End: 6, Before: "fine thanks"},
+				{Kind: yttranscript.ChangeRemoved, Start: 10, End: 11, Before: "bye"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := yttranscript.Diff(a, &yttranscript.Transcript{Texts: tt.b})
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: Diff = %+v, want %+v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: change %d = %+v, want %+v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
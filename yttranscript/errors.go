@@ -0,0 +1,140 @@
+package yttranscript
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrEmptyTranscript is returned when a caption track contains no text.
+var ErrEmptyTranscript = errors.New("transcript is empty")
+
+// Errors matched by PlayabilityError for the playability statuses YouTube
+// reports. Use errors.Is to test for them.
+var (
+	ErrNotPlayable       = errors.New("video not playable")
+	ErrLoginRequired     = errors.New("login required")
+	ErrAgeCheckRequired  = errors.New("age check required")
+	ErrUnplayable        = errors.New("video unplayable")
+	ErrLiveStreamOffline = errors.New("live stream offline")
+	ErrVideoUnavailable  = errors.New("video unavailable")
+
+	// ErrBotCheck matches LOGIN_REQUIRED responses asking to confirm that
+	// the request is not from a bot. Such errors also match ErrLoginRequired.
+	ErrBotCheck                = errors.New("bot check required")
+	ErrAgeVerificationRequired = errors.New("age verification required")
+	ErrContentCheckRequired    = errors.New("content check required")
+)
+
+// playabilityErrors maps playabilityStatus.status values to their errors.
+var playabilityErrors = map[string]error{
+	"LOGIN_REQUIRED":      ErrLoginRequired,
+	"AGE_CHECK_REQUIRED":  ErrAgeCheckRequired,
+	"UNPLAYABLE":          ErrUnplayable,
+	"LIVE_STREAM_OFFLINE": ErrLiveStreamOffline,
+	"ERROR":               ErrVideoUnavailable,
+
+	"AGE_VERIFICATION_REQUIRED": ErrAgeVerificationRequired,
+	"CONTENT_CHECK_REQUIRED":    ErrContentCheckRequired,
+}
+
+// remediationHints suggests what a caller can do about each blocking error.
+var remediationHints = map[error]string{
+	ErrBotCheck:                "YouTube flagged the request as automated; slow down, try other client profiles with WithClientProfiles, or use a different network",
+	ErrLoginRequired:           "the video requires a signed-in account, it may be private or members-only",
+	ErrAgeCheckRequired:        "the video is age-restricted; if the embedded clients in AgeGateProfiles were refused too, it needs a signed-in account",
+	ErrAgeVerificationRequired: "the video requires age verification on a signed-in account",
+	ErrContentCheckRequired:    "the video is behind a content warning that must be acknowledged before playback",
+}
+
+// PlayabilityError is returned when the player response reports a video as
+// not playable. It carries the details YouTube gives for the block.
+type PlayabilityError struct {
+	Status             string   // Raw playabilityStatus.status, e.g. "LOGIN_REQUIRED".
+	Reason             string   // Human-readable reason.
+	Subreason          string   // Additional explanation from the error screen, if any.
+	AvailableCountries []string // Countries the video is available in, for region blocks.
+	Hint               string   // Suggested remediation, if one is known.
+}
+
+func (e *PlayabilityError) Error() string {
+	msg := fmt.Sprintf("video not playable (%s)", e.Status)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Subreason != "" {
+		msg += " (" + e.Subreason + ")"
+	}
+	if e.Hint != "" {
+		msg += "; hint: " + e.Hint
+	}
+	return msg
+}
+
+// Is reports whether target is the error for this status or ErrNotPlayable.
+func (e *PlayabilityError) Is(target error) bool {
+	if target == ErrNotPlayable {
+		return true
+	}
+	if target == ErrBotCheck {
+		return e.isBotCheck()
+	}
+	err, ok := playabilityErrors[e.Status]
+	return ok && target == err
+}
+
+// isBotCheck reports whether a login wall is YouTube's bot check rather than
+// a video that genuinely needs an account.
+func (e *PlayabilityError) isBotCheck() bool {
+	if e.Status != "LOGIN_REQUIRED" {
+		return false
+	}
+	text := strings.ToLower(e.Reason + " " + e.Subreason)
+	return strings.Contains(text, "not a bot") || strings.Contains(text, "confirm you")
+}
+
+func (e *PlayabilityError) hint() string {
+	if e.isBotCheck() {
+		return remediationHints[ErrBotCheck]
+	}
+	return remediationHints[playabilityErrors[e.Status]]
+}
+
+func newPlayabilityError(playerResponse *PlayerResponse) *PlayabilityError {
+	status := playerResponse.PlayabilityStatus
+	renderer := status.ErrorScreen.PlayerErrorMessageRenderer
+
+	reason := status.Reason
+	if reason == "" {
+		reason = renderer.Reason.String()
+	}
+	err := &PlayabilityError{
+		Status:             status.Status,
+		Reason:             reason,
+		Subreason:          renderer.Subreason.String(),
+		AvailableCountries: playerResponse.Microformat.PlayerMicroformatRenderer.AvailableCountries,
+	}
+	err.Hint = err.hint()
+	return err
+}
+
+// ErrLanguageMismatch is matched by LanguageMismatchError. Use errors.Is to
+// test for it.
+var ErrLanguageMismatch = errors.New("transcript language mismatch")
+
+// LanguageMismatchError reports captions whose detected language differs from
+// the language of the track they were served as.
+type LanguageMismatchError struct {
+	Labeled    string
+	Detected   string
+	Confidence float64
+}
+
+func (e *LanguageMismatchError) Error() string {
+	return fmt.Sprintf("transcript labeled '%s' appears to be in '%s' (confidence %.2f)", e.Labeled, e.Detected, e.Confidence)
+}
+
+// Is reports whether target is ErrLanguageMismatch.
+func (e *LanguageMismatchError) Is(target error) bool {
+	return target == ErrLanguageMismatch
+}
@@ -0,0 +1,10 @@
+package yttranscript
+
+import "net/http"
+
+// WrapTransport replaces the client's transport with wrap applied to the
+// pooled transport the connection options configure, so that tests can
+// route requests to a fake server without bypassing those options.
+func WrapTransport(c *Client, wrap func(http.RoundTripper) http.RoundTripper) {
+	c.httpClient.Transport = wrap(c.transport)
+}
@@ -0,0 +1,72 @@
+package yttranscript
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// feedURL is the public Atom feed of a channel's latest uploads. It lists
+// only the 15 most recent videos and is not paginated.
+const feedURL = "https://www.youtube.com/feeds/videos.xml?channel_id="
+
+// FeedSize is the number of entries YouTube includes in a channel feed.
+// A poll returning this many unseen videos may have missed older ones.
+const FeedSize = 15
+
+// FeedEntry is one upload listed in a channel feed.
+type FeedEntry struct {
+	VideoID   string
+	ChannelID string
+	Title     string
+	Published time.Time
+}
+
+// atomFeed is the subset of the channel Atom feed that is used.
+type atomFeed struct {
+	Entries []struct {
+		VideoID   string `xml:"http://www.youtube.com/xml/schemas/2015 videoId"`
+		ChannelID string `xml:"http://www.youtube.com/xml/schemas/2015 channelId"`
+		Title     string `xml:"http://www.w3.org/2005/Atom title"`
+		Published string `xml:"http://www.w3.org/2005/Atom published"`
+	} `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+// GetChannelFeed returns the latest uploads of a channel from its public
+// RSS feed, newest first. This is a single cheap request, which makes it
+// suited to polling a channel for new videos.
+func (c *Client) GetChannelFeed(channelID string) ([]FeedEntry, error) {
+	body, err := c.fetchURL(feedURL + url.QueryEscape(channelID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch channel feed: %w", err)
+	}
+	entries, err := parseFeed([]byte(body))
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Debug("fetched channel feed", "channel", channelID, "entries", len(entries))
+	return entries, nil
+}
+
+func parseFeed(body []byte) ([]FeedEntry, error) {
+	var feed atomFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal channel feed: %w", err)
+	}
+
+	entries := make([]FeedEntry, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		if e.VideoID == "" {
+			continue
+		}
+		published, _ := time.Parse(time.RFC3339, e.Published)
+		entries = append(entries, FeedEntry{
+			VideoID:   e.VideoID,
+			ChannelID: e.ChannelID,
+			Title:     e.Title,
+			Published: published,
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,127 @@
+package yttranscript
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// alignmentResolution is the bucket size, in seconds, used when voting
+	// for the offset between two copies of the same content.
+	alignmentResolution = 0.5
+	// minAlignmentVotes is how many identical lines two transcripts must
+	// agree on before they are considered copies of the same content.
+	minAlignmentVotes = 2
+)
+
+// FillGaps returns a copy of the transcript in which gaps of at least minGap
+// without captions are filled with segments from others, typically
+// re-uploads of the same content. Each other transcript is aligned to this
+// one by the time offset at which most of their identical lines agree, so
+// re-uploads with a different intro still line up; transcripts that cannot
+// be aligned are skipped with a warning.
+//
+// Every segment's Source records the video it was taken from.
+func (t *Transcript) FillGaps(minGap time.Duration, others ...*Transcript) *Transcript {
+	merged := make([]Text, len(t.Texts))
+	for i, text := range t.Texts {
+		if text.Source == "" {
+			text.Source = t.VideoID
+		}
+		merged[i] = text
+	}
+	warnings := append([]string(nil), t.Warnings...)
+
+	for _, other := range others {
+		offset, ok := alignOffset(merged, other.Texts)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("could not align transcript of %s", other.VideoID))
+			continue
+		}
+
+		gaps := captionGaps(merged, minGap.Seconds())
+		for _, text := range other.Texts {
+			text.Start += offset
+			if text.Start < 0 || !inGap(gaps, text.Start+text.Duration/2) {
+				continue
+			}
+			if text.Source == "" {
+				text.Source = other.VideoID
+			}
+			merged = append(merged, text)
+		}
+		sort.SliceStable(merged, func(i, j int) bool { return merged[i].Start < merged[j].Start })
+	}
+
+	out := t.withTexts(merged)
+	out.Warnings = warnings
+	return out
+}
+
+// alignOffset returns the offset to add to the start times of other to line
+// it up with base, found by voting over pairs of identical lines.
+func alignOffset(base, other []Text) (float64, bool) {
+	starts := make(map[string][]float64)
+	for _, text := range base {
+		if key := normalizeForDiff(text.Content); key != "" {
+			starts[key] = append(starts[key], text.Start)
+		}
+	}
+
+	votes := make(map[int]int)
+	for _, text := range other {
+		for _, start := range starts[normalizeForDiff(text.Content)] {
+			votes[int(math.Round((start-text.Start)/alignmentResolution))]++
+		}
+	}
+
+	best, bestVotes := 0, 0
+	for bucket, count := range votes {
+		if count > bestVotes || (count == bestVotes && abs(bucket) < abs(best)) {
+			best, bestVotes = bucket, count
+		}
+	}
+	if bestVotes < minAlignmentVotes {
+		return 0, false
+	}
+	return float64(best) * alignmentResolution, true
+}
+
+// captionGap is a stretch of time with no captions.
+type captionGap struct {
+	from, to float64
+}
+
+// captionGaps returns the gaps of at least minGap seconds between the
+// segments of texts, which must be sorted by start time, including the time
+// before the first segment.
+func captionGaps(texts []Text, minGap float64) []captionGap {
+	var gaps []captionGap
+	covered := 0.0
+	for _, text := range texts {
+		if text.Start-covered >= minGap {
+			gaps = append(gaps, captionGap{from: covered, to: text.Start})
+		}
+		covered = math.Max(covered, text.End())
+	}
+	gaps = append(gaps, captionGap{from: covered, to: math.Inf(1)})
+	return gaps
+}
+
+func inGap(gaps []captionGap, t float64) bool {
+	for _, gap := range gaps {
+		if t >= gap.from && t < gap.to {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
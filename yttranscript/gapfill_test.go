@@ -0,0 +1,83 @@
+package yttranscript_test
+
+import (
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestFillGaps(t *testing.T) {
+	base := &yttranscript.Transcript{VideoID: "A", Texts: []yttranscript.Text{
+		{Start: 0, Duration: 2, Content: "one"},
+		{Start: 2, Duration: 2, Content: "two"},
+		{Start: 10, Duration: 2, Content: "five"},
+		{Start: 12, Duration: 2, Content: "six"},
+	}}
+	// A re-upload with a five second longer intro that kept the missing lines.
+	reupload := &yttranscript.Transcript{VideoID: "B", Texts: []yttranscript.Text{
+		{Start: 5, Duration: 2, Content: "One!"},
+		{Start: 7, Duration: 2, Content: "two"},
+		{Start: 9, Duration: 2, Content: "three"},
+		{Start: 11, Duration: 2, Content: "four"},
+		{Start: 15, Duration: 2, Content: "five"},
+		{Start: 17, Duration: 2, Content: "six"},
+	}}
+	unrelated := &yttranscript.Transcript{VideoID: "C", Texts: []yttranscript.Text{
+		{Start: 3, Duration: 2, Content: "one"},
+		{Start: 5, Duration: 2, Content: "something else"},
+	}}
+
+	type segment struct {
+		start   float64
+		content string
+		source  string
+	}
+	tests := []struct {
+		name         string
+		minGap       time.Duration
+		others       []*yttranscript.Transcript
+		want         []segment
+		wantWarnings int
+	}{
+		{
+			name:   "fills aligned gap",
+			minGap: 3 * time.Second,
+			others: []*yttranscript.Transcript{reupload},
+			want: []segment{
+				{0, "one", "A"}, {2, "two", "A"}, {4, "three", "B"}, {6, "four", "B"}, {10, "five", "A"}, {12, "six", "A"},
+			},
+		},
+		{
+			name:   "gap shorter than minimum",
+			minGap: 10 * time.Second,
+			others: []*yttranscript.Transcript{reupload},
+			want:   []segment{{0, "one", "A"}, {2, "two", "A"}, {10, "five", "A"}, {12, "six", "A"}},
+		},
+		{
+			name:         "unaligned transcript skipped",
+			minGap:       3 * time.Second,
+			others:       []*yttranscript.Transcript{unrelated},
+			want:         []segment{{0, "one", "A"}, {2, "two", "A"}, {10, "five", "A"}, {12, "six", "A"}},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		got := base.FillGaps(tt.minGap, tt.others...)
+		if len(got.Texts) != len(tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got.Texts, tt.want)
+			continue
+		}
+		for i, text := range got.Texts {
+			if (segment{text.Start, text.Content, text.Source}) != tt.want[i] {
+				t.Errorf("%s: segment %d = %v %q from %q, want %+v", tt.name, i, text.Start, text.Content, text.Source, tt.want[i])
+			}
+		}
+		if len(got.Warnings) != tt.wantWarnings {
+			t.Errorf("%s: warnings = %q, want %d", tt.name, got.Warnings, tt.wantWarnings)
+		}
+	}
+	if base.Texts[0].Source != "" || len(base.Texts) != 4 {
+		t.Error("FillGaps modified its receiver")
+	}
+}
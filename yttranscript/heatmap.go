@@ -0,0 +1,52 @@
+package yttranscript
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// HeatMarker is one bar of a video's "most replayed" graph.
+type HeatMarker struct {
+	Start     float64 // Start time in seconds.
+	Duration  float64 // Duration in seconds.
+	Intensity float64 // Replay intensity normalized to 0..1 across the video.
+}
+
+// End returns the time in seconds at which the marker ends.
+func (m HeatMarker) End() float64 {
+	return m.Start + m.Duration
+}
+
+// heatMarkerRegex matches the markers of the HEATSEEKER markers map in the
+// initial data embedded in the watch page.
+var heatMarkerRegex = regexp.MustCompile(`"startMillis":"(\d+)","durationMillis":"(\d+)","intensityScoreNormalized":([0-9.eE+-]+)`)
+
+// GetHeatmap returns the "most replayed" graph of a video. YouTube only shows
+// it for videos with enough views, so a nil result without error is common.
+func (c *Client) GetHeatmap(videoID string) ([]HeatMarker, error) {
+	htmlContent, err := c.fetchWatchPage(context.Background(), videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch video page: %w", err)
+	}
+	return parseHeatmap(htmlContent), nil
+}
+
+func parseHeatmap(htmlContent string) []HeatMarker {
+	var markers []HeatMarker
+	for _, matches := range heatMarkerRegex.FindAllStringSubmatch(htmlContent, -1) {
+		start, _ := strconv.ParseInt(matches[1], 10, 64)
+		duration, _ := strconv.ParseInt(matches[2], 10, 64)
+		intensity, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			continue
+		}
+		markers = append(markers, HeatMarker{
+			Start:     float64(start) / 1000,
+			Duration:  float64(duration) / 1000,
+			Intensity: intensity,
+		})
+	}
+	return markers
+}
@@ -0,0 +1,121 @@
+package yttranscript
+
+import "strings"
+
+// languageAliases maps deprecated language subtags, some of which YouTube
+// still uses, to their current form.
+var languageAliases = map[string]string{
+	"iw": "he",
+	"in": "id",
+	"ji": "yi",
+	"jw": "jv",
+	"mo": "ro",
+}
+
+// implicitScripts gives the script implied by a language and region when a
+// tag does not name one explicitly.
+var implicitScripts = map[string]string{
+	"zh-cn": "hans",
+	"zh-sg": "hans",
+	"zh-my": "hans",
+	"zh-tw": "hant",
+	"zh-hk": "hant",
+	"zh-mo": "hant",
+}
+
+// languageTag is a BCP-47 tag reduced to the parts relevant for matching.
+type languageTag struct {
+	base   string
+	script string
+	region string
+}
+
+func parseLanguageTag(tag string) languageTag {
+	parts := strings.Split(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), "-")
+	t := languageTag{base: parts[0]}
+	if alias, ok := languageAliases[t.base]; ok {
+		t.base = alias
+	}
+	for _, part := range parts[1:] {
+		switch {
+		case len(part) == 4 && t.script == "" && t.region == "":
+			t.script = part
+		case (len(part) == 2 || len(part) == 3 && isDigits(part)) && t.region == "":
+			t.region = part
+		}
+	}
+	if t.script == "" && t.region != "" {
+		t.script = implicitScripts[t.base+"-"+t.region]
+	}
+	return t
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// matchScore rates how well an available language serves a requested one.
+// Zero means no match.
+func matchScore(requested, available languageTag) int {
+	if requested.base != available.base {
+		return 0
+	}
+	if requested.script != "" && available.script != "" && requested.script != available.script {
+		// Different writing systems, e.g. zh-Hans and zh-Hant, are not
+		// interchangeable.
+		return 0
+	}
+
+	score := 1
+	if requested.script == available.script {
+		score += 4
+	}
+	switch {
+	case requested.region == available.region:
+		score += 2
+	case available.region == "":
+		// A generic track is a better fallback than another region's.
+		score++
+	}
+	return score
+}
+
+// MatchLanguage returns the track that best serves the BCP-47 language tag.
+// An exact match wins; otherwise tracks in the same base language are
+// considered, preferring matching script, then matching region, then a
+// generic track over one for a different region. So "en" finds "en-US", and
+// "en-GB" falls back to "en" and then to "en-US". Earlier tracks win ties.
+func MatchLanguage(tracks []CaptionTrack, tag string) (CaptionTrack, bool) {
+	codes := make([]string, len(tracks))
+	for i, track := range tracks {
+		codes[i] = track.LanguageCode
+	}
+	if i := matchLanguageCode(codes, tag); i >= 0 {
+		return tracks[i], true
+	}
+	return CaptionTrack{}, false
+}
+
+// matchLanguageCode returns the index of the code that best serves tag as
+// MatchLanguage chooses it, or -1 if none does.
+func matchLanguageCode(codes []string, tag string) int {
+	for i, code := range codes {
+		if strings.EqualFold(code, tag) {
+			return i
+		}
+	}
+
+	requested := parseLanguageTag(tag)
+	best, bestScore := -1, 0
+	for i, code := range codes {
+		if score := matchScore(requested, parseLanguageTag(code)); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
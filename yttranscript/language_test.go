@@ -0,0 +1,42 @@
+package yttranscript_test
+
+import (
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestMatchLanguage(t *testing.T) {
+	tracks := func(codes ...string) []yttranscript.CaptionTrack {
+		var tracks []yttranscript.CaptionTrack
+		for _, code := range codes {
+			tracks = append(tracks, yttranscript.CaptionTrack{LanguageCode: code})
+		}
+		return tracks
+	}
+	tests := []struct {
+		name      string
+		available []yttranscript.CaptionTrack
+		tag       string
+		want      string // Empty when no track should match.
+	}{
+		{name: "exact", available: tracks("de", "en"), tag: "en", want: "en"},
+		{name: "exact ignores case", available: tracks("en-gb"), tag: "en-GB", want: "en-gb"},
+		{name: "base finds region", available: tracks("de", "en-US"), tag: "en", want: "en-US"},
+		{name: "region falls back to generic", available: tracks("en-US", "en"), tag: "en-GB", want: "en"},
+		{name: "region falls back to other region", available: tracks("en-US"), tag: "en-GB", want: "en-US"},
+		{name: "underscore separator", available: tracks("pt-BR"), tag: "pt_br", want: "pt-BR"},
+		{name: "deprecated subtag", available: tracks("iw"), tag: "he", want: "iw"},
+		{name: "implied script", available: tracks("zh-Hans", "zh-Hant"), tag: "zh-TW", want: "zh-Hant"},
+		{name: "scripts differ", available: tracks("zh-Hans"), tag: "zh-Hant"},
+		{name: "earlier track wins tie", available: tracks("en-US", "en-AU"), tag: "en-GB", want: "en-US"},
+		{name: "no match", available: tracks("de", "fr"), tag: "en"},
+		{name: "no tracks", tag: "en"},
+	}
+	for _, tt := range tests {
+		got, ok := yttranscript.MatchLanguage(tt.available, tt.tag)
+		if ok != (tt.want != "") || got.LanguageCode != tt.want {
+			t.Errorf("%s: MatchLanguage(%q) = %q, %v; want %q", tt.name, tt.tag, got.LanguageCode, ok, tt.want)
+		}
+	}
+}
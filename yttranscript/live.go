@@ -0,0 +1,124 @@
+package yttranscript
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	liveMaxBackoff     = time.Minute
+	liveChannelBufSize = 64
+)
+
+// livePollInterval is how often a live track is fetched. A variable so tests
+// can poll faster.
+var livePollInterval = 5 * time.Second
+
+// LiveText is a caption segment of a live broadcast. Seq numbers the
+// broadcast's segments in order from zero. A segment revised after it was
+// delivered, as happens to the newest caption while it is still being
+// written, is delivered again with the same Seq and Revised set, and replaces
+// the earlier one even when its Start moved.
+type LiveText struct {
+	Text
+	Seq     int
+	Revised bool
+}
+
+// TailLiveTranscript follows the captions of a live broadcast in languageCode,
+// or in its first track if languageCode is empty, and delivers new and
+// revised segments on the returned channel as they appear. The track is
+// chosen like GetTranscript does, including WithLanguagePolicy.
+//
+// The timedtext track is polled every five seconds. The endpoint cannot be
+// asked for new segments only, so every poll downloads the whole track so far
+// and costs more the longer the broadcast has run; sequence numbers are
+// assigned by aligning each poll with the segments delivered before. When a
+// poll fails, for example because the signed track URL expired, the player
+// response is fetched again and polling resumes with exponential backoff.
+// The channel is closed when ctx is done.
+func (c *Client) TailLiveTranscript(ctx context.Context, videoID, languageCode string, opts ...CallOption) (<-chan LiveText, error) {
+	call := newCallConfig(opts)
+	track, err := c.resolveLiveTrack(ctx, videoID, languageCode, call)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make(chan LiveText, liveChannelBufSize)
+	go c.tailLive(ctx, videoID, languageCode, call, track, texts)
+	return texts, nil
+}
+
+func (c *Client) resolveLiveTrack(ctx context.Context, videoID, languageCode string, call callConfig) (CaptionTrack, error) {
+	playerResponse, err := c.getPlayerResponse(ctx, videoID, call)
+	if err != nil {
+		return CaptionTrack{}, fmt.Errorf("failed to get player response: %w", err)
+	}
+	if len(playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks) == 0 {
+		return CaptionTrack{}, fmt.Errorf("no transcripts available for this video")
+	}
+	track, _, err := selectTrack(playerResponse, languageCode, call.languagePolicy)
+	return track, err
+}
+
+func (c *Client) tailLive(ctx context.Context, videoID, languageCode string, call callConfig, track CaptionTrack, texts chan<- LiveText) {
+	defer close(texts)
+
+	var delivered []Text // Segments sent so far, indexed by Seq.
+	backoff := livePollInterval
+	for {
+		transcript, err := c.fetchTranscript(ctx, track, CleanOptions{})
+		if err == nil {
+			backoff = livePollInterval
+			base := liveSeq(delivered, transcript.Texts)
+			for i, text := range transcript.Texts {
+				live := LiveText{Text: text, Seq: base + i}
+				if live.Seq < len(delivered) {
+					if delivered[live.Seq] == text {
+						continue
+					}
+					live.Revised = true
+				}
+				select {
+				case texts <- live:
+				case <-ctx.Done():
+					return
+				}
+				if live.Revised {
+					delivered[live.Seq] = text
+				} else {
+					delivered = append(delivered, text)
+				}
+			}
+		} else if ctx.Err() == nil {
+			// Reconnect: the track URL carries an expiring signature, so
+			// resolve a fresh one before the next poll.
+			if refreshed, err := c.resolveLiveTrack(ctx, videoID, languageCode, call); err == nil {
+				track = refreshed
+			}
+			backoff = min(backoff*2, liveMaxBackoff)
+			c.logger.Info("live caption poll failed, backing off", "video_id", videoID,
+				"backoff", backoff, "error", err)
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// liveSeq returns the Seq of the first of a poll's texts. A poll is aligned
+// on the first delivered segment starting no earlier than its first text, so
+// a track whose oldest segments fell out of the broadcast's window keeps its
+// numbering, and later texts are numbered by position, so a revision that
+// moves a segment's start replaces it rather than adding a copy.
+func liveSeq(delivered, texts []Text) int {
+	if len(texts) == 0 {
+		return len(delivered)
+	}
+	return sort.Search(len(delivered), func(i int) bool { return delivered[i].Start >= texts[0].Start })
+}
@@ -0,0 +1,101 @@
+package yttranscript
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTailLive(t *testing.T) {
+	previous := livePollInterval
+	livePollInterval = 10 * time.Millisecond
+	defer func() { livePollInterval = previous }()
+
+	var mu sync.Mutex
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	setBody := func(s string) {
+		mu.Lock()
+		body = s
+		mu.Unlock()
+	}
+
+	client, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	polls := []struct {
+		name string
+		xml  string
+		want []LiveText
+	}{
+		{
+			name: "first poll",
+			xml:  `<transcript><text start="1" dur="2">hello</text><text start="3" dur="1">wor</text></transcript>`,
+			want: []LiveText{
+				{Text: Text{Start: 1, Duration: 2, Content: "hello"}, Seq: 0},
+				{Text: Text{Start: 3, Duration: 1, Content: "wor"}, Seq: 1},
+			},
+		},
+		{
+			name: "revision and new segment",
+			xml:  `<transcript><text start="1" dur="2">hello</text><text start="3" dur="2">world</text><text start="5" dur="1">again</text></transcript>`,
+			want: []LiveText{
+				{Text: Text{Start: 3, Duration: 2, Content: "world"}, Seq: 1, Revised: true},
+				{Text: Text{Start: 5, Duration: 1, Content: "again"}, Seq: 2},
+			},
+		},
+		{
+			name: "revision moves the start",
+			xml:  `<transcript><text start="1" dur="2">hello</text><text start="3" dur="2">world</text><text start="5.5" dur="1">again!</text></transcript>`,
+			want: []LiveText{
+				{Text: Text{Start: 5.5, Duration: 1, Content: "again!"}, Seq: 2, Revised: true},
+			},
+		},
+		{
+			name: "oldest segments left the window",
+			xml:  `<transcript><text start="3" dur="2">world</text><text start="5.5" dur="1">again!</text><text start="7" dur="1">bye</text></transcript>`,
+			want: []LiveText{
+				{Text: Text{Start: 7, Duration: 1, Content: "bye"}, Seq: 3},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	setBody(polls[0].xml)
+	texts := make(chan LiveText, liveChannelBufSize)
+	go client.tailLive(ctx, "live", "en", newCallConfig(nil), CaptionTrack{BaseURL: srv.URL, LanguageCode: "en"}, texts)
+	for _, poll := range polls {
+		setBody(poll.xml)
+		for _, want := range poll.want {
+			select {
+			case got := <-texts:
+				if got.Seq != want.Seq || got.Revised != want.Revised || got.Start != want.Start ||
+					got.Duration != want.Duration || got.Content != want.Content {
+					t.Errorf("%s: got %+v, want %+v", poll.name, got, want)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timed out waiting for %+v", poll.name, want)
+			}
+		}
+	}
+	select {
+	case got := <-texts:
+		t.Errorf("unchanged track delivered %+v again", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	for range texts {
+	}
+}
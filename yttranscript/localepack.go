@@ -0,0 +1,206 @@
+package yttranscript
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// localePackData holds the built-in locale packs, one JSON file per
+// language.
+//
+//go:embed localepacks/*.json
+var localePackData embed.FS
+
+// fallbackLocale is the built-in pack used for languages without one.
+const fallbackLocale = "en"
+
+// LocalePack holds the language-specific vocabulary of the cleaning steps.
+// Packs for common languages are built in; ParseLocalePack and
+// LoadLocalePack read others, or replacements, from JSON files with the same
+// field names as the struct tags.
+type LocalePack struct {
+	// Language is the language code the pack is for, such as "de". Region
+	// subtags are ignored when matching transcripts to packs.
+	Language string `json:"language"`
+	// SoundWords mark a parenthesized annotation such as "(Applaus)" as a
+	// sound description when they occur in it, in any case. Inflected forms
+	// must be listed separately.
+	SoundWords []string `json:"sound_words"`
+	// SpeakerMarkers are literal prefixes of lines starting a new speaker's
+	// turn, such as ">>" or the dash used in Spanish and French captions.
+	// Include the space after markers that could also start ordinary text.
+	SpeakerMarkers []string `json:"speaker_markers"`
+	// FillerWords are hesitation words such as "um" removed by
+	// CleanOptions.RemoveFillers.
+	FillerWords []string `json:"filler_words"`
+
+	compileOnce         sync.Once
+	soundWords          map[string]bool
+	fillerWords         map[string]bool
+	speakerRegex        *regexp.Regexp
+	speakerLabelRegex   *regexp.Regexp // Captures the marker and the name.
+	speakerBracketRegex *regexp.Regexp // Captures the marker and the bracketed name.
+}
+
+var (
+	parenRegex         = regexp.MustCompile(`\([^)]*\)`)
+	speakerNamePattern = `(?:\p{Lu}[\p{Lu}\p{N} .'-]*:\s*)?`
+	// speakerLabelPattern is an uppercase name of up to four words.
+	speakerLabelPattern = `\p{Lu}[\p{Lu}\p{N}.'-]*(?: \p{Lu}[\p{Lu}\p{N}.'-]*){0,3}`
+)
+
+var (
+	builtinPacksOnce sync.Once
+	builtinPacks     map[string]*LocalePack
+)
+
+// BuiltinLocalePack returns the built-in pack for a language, or nil if there
+// is none.
+func BuiltinLocalePack(languageCode string) *LocalePack {
+	builtinPacksOnce.Do(func() {
+		builtinPacks = make(map[string]*LocalePack)
+		entries, _ := localePackData.ReadDir("localepacks")
+		for _, entry := range entries {
+			data, err := localePackData.ReadFile(path.Join("localepacks", entry.Name()))
+			if err != nil {
+				continue
+			}
+			if pack, err := ParseLocalePack(data); err == nil {
+				builtinPacks[pack.Language] = pack
+			}
+		}
+	})
+	return builtinPacks[parseLanguageTag(languageCode).base]
+}
+
+// ParseLocalePack decodes a locale pack from JSON.
+func ParseLocalePack(data []byte) (*LocalePack, error) {
+	var pack LocalePack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("failed to decode locale pack: %w", err)
+	}
+	if pack.Language == "" {
+		return nil, fmt.Errorf("locale pack has no language")
+	}
+	pack.Language = parseLanguageTag(pack.Language).base
+	return &pack, nil
+}
+
+// LoadLocalePack reads a locale pack from a JSON file.
+func LoadLocalePack(path string) (*LocalePack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locale pack: %w", err)
+	}
+	return ParseLocalePack(data)
+}
+
+// localePack picks the pack for a transcript language: a user-supplied one,
+// else a built-in one, else the English pack.
+func localePack(languageCode string, packs []*LocalePack) *LocalePack {
+	base := parseLanguageTag(languageCode).base
+	for _, pack := range packs {
+		if pack.Language == base {
+			return pack
+		}
+	}
+	if pack := BuiltinLocalePack(base); pack != nil {
+		return pack
+	}
+	return BuiltinLocalePack(fallbackLocale)
+}
+
+func (p *LocalePack) compile() {
+	p.compileOnce.Do(func() {
+		p.soundWords = wordSet(p.SoundWords)
+		p.fillerWords = wordSet(p.FillerWords)
+		markers := make([]string, len(p.SpeakerMarkers))
+		for i, marker := range p.SpeakerMarkers {
+			markers[i] = regexp.QuoteMeta(marker)
+		}
+		pattern, marker := `(?m)^\s*`, `()`
+		if len(markers) > 0 {
+			pattern += `(?:(?:` + strings.Join(markers, "|") + `)\s*)?`
+			marker = `(` + strings.Join(markers, "|") + `)?`
+		}
+		p.speakerRegex = regexp.MustCompile(pattern + speakerNamePattern)
+		p.speakerLabelRegex = regexp.MustCompile(`^\s*` + marker + `\s*(?:(` + speakerLabelPattern + `):\s*)?`)
+		p.speakerBracketRegex = regexp.MustCompile(`^\s*(?:` + marker + `\s*)?\[([^\]]+)\]:?\s*`)
+	})
+}
+
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[strings.ToLower(word)] = true
+	}
+	return set
+}
+
+// removeSoundDescriptions strips parenthesized annotations containing one of
+// the pack's sound words. Words are split on anything but letters and digits,
+// so that the check works for every script.
+func (p *LocalePack) removeSoundDescriptions(content string) string {
+	p.compile()
+	return parenRegex.ReplaceAllStringFunc(content, func(annotation string) string {
+		if p.isSoundDescription(annotation) {
+			return ""
+		}
+		return annotation
+	})
+}
+
+// isSoundDescription reports whether an annotation such as "Music" or
+// "Background noise" contains one of the pack's sound words.
+func (p *LocalePack) isSoundDescription(annotation string) bool {
+	p.compile()
+	for _, word := range splitWords(annotation) {
+		if p.soundWords[strings.ToLower(word)] {
+			return true
+		}
+	}
+	return false
+}
+
+// removeSpeakerLabels strips the speaker markers and upper-case names
+// starting lines.
+func (p *LocalePack) removeSpeakerLabels(content string) string {
+	p.compile()
+	return p.speakerRegex.ReplaceAllString(content, "")
+}
+
+// removeFillers drops the pack's filler words, along with punctuation
+// attached to them, from every line.
+func (p *LocalePack) removeFillers(content string) string {
+	p.compile()
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		kept := fields[:0]
+		for _, field := range fields {
+			word := strings.TrimFunc(field, func(r rune) bool { return !isWordRune(r) })
+			if !p.fillerWords[strings.ToLower(word)] {
+				kept = append(kept, field)
+			}
+		}
+		if len(kept) < len(fields) {
+			lines[i] = strings.Join(kept, " ")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func splitWords(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return !isWordRune(r) })
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
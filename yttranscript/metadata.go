@@ -0,0 +1,79 @@
+package yttranscript
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Metadata describes a video and the captions available for it.
+type Metadata struct {
+	VideoID       string         `json:"video_id"`
+	Title         string         `json:"title"`
+	Channel       string         `json:"channel"`
+	ChannelID     string         `json:"channel_id"`
+	LengthSeconds int            `json:"length_seconds"`
+	PublishDate   string         `json:"publish_date"`
+	Category      string         `json:"category,omitempty"`
+	Captions      CaptionSummary `json:"captions"`
+}
+
+// Duration returns the length of the video.
+func (m *Metadata) Duration() time.Duration {
+	return time.Duration(m.LengthSeconds) * time.Second
+}
+
+// CaptionSummary counts the caption tracks of a video.
+type CaptionSummary struct {
+	Total     int            `json:"total"`
+	Manual    int            `json:"manual"`
+	Automatic int            `json:"automatic"`
+	Languages []string       `json:"languages"`
+	Tracks    []TrackSummary `json:"tracks"`
+}
+
+// TrackSummary is the language and kind of one caption track.
+type TrackSummary struct {
+	LanguageCode string `json:"language_code"`
+	Kind         string `json:"kind"` // "asr" for automatic captions, otherwise "manual".
+}
+
+// GetMetadata fetches a video's metadata and a summary of its caption tracks.
+func (c *Client) GetMetadata(videoID string, opts ...CallOption) (*Metadata, error) {
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, newCallConfig(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player response: %w", err)
+	}
+	return newMetadata(videoID, playerResponse), nil
+}
+
+func newMetadata(videoID string, playerResponse *PlayerResponse) *Metadata {
+	details := playerResponse.VideoDetails
+	microformat := playerResponse.Microformat.PlayerMicroformatRenderer
+
+	seconds, _ := strconv.Atoi(details.LengthSeconds)
+	metadata := &Metadata{
+		VideoID:       videoID,
+		Title:         details.Title,
+		Channel:       details.Author,
+		ChannelID:     details.ChannelID,
+		LengthSeconds: seconds,
+		PublishDate:   microformat.PublishDate,
+		Category:      microformat.Category,
+	}
+
+	for _, track := range playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks {
+		metadata.Captions.Total++
+		kind := "manual"
+		if track.Kind == "asr" {
+			metadata.Captions.Automatic++
+			kind = "asr"
+		} else {
+			metadata.Captions.Manual++
+		}
+		metadata.Captions.Languages = append(metadata.Captions.Languages, track.LanguageCode)
+		metadata.Captions.Tracks = append(metadata.Captions.Tracks, TrackSummary{LanguageCode: track.LanguageCode, Kind: kind})
+	}
+	return metadata
+}
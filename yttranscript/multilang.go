@@ -0,0 +1,109 @@
+package yttranscript
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// defaultLanguageConcurrency is how many tracks GetTranscripts downloads at
+// once unless WithLanguageConcurrency says otherwise.
+const defaultLanguageConcurrency = 4
+
+// LanguageFailure records why one language of a multi-language request could
+// not be fetched.
+type LanguageFailure struct {
+	LanguageCode string
+	Err          error
+}
+
+// PartialError is returned by GetTranscripts when some of the requested
+// languages failed. The transcripts that succeeded are returned alongside it.
+type PartialError struct {
+	Requested int
+	Failures  []LanguageFailure
+}
+
+func (e *PartialError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		parts[i] = fmt.Sprintf("%s: %v", failure.LanguageCode, failure.Err)
+	}
+	return fmt.Sprintf("failed to fetch %d of %d languages: %s", len(e.Failures), e.Requested, strings.Join(parts, "; "))
+}
+
+// Unwrap returns the individual failures so that errors.Is and errors.As see
+// through the report.
+func (e *PartialError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// GetTranscripts fetches the transcripts of a video in several languages with
+// a single player request. A language without its own track fails like it
+// does in GetTranscript; pass WithLanguagePolicy with
+// LanguageSubstituteTranslation to get a machine translation instead.
+//
+// The tracks are downloaded concurrently, at most four at a time unless
+// WithLanguageConcurrency sets another limit. Languages are fetched
+// independently: when some fail, the others are still
+// returned, in request order, together with a *PartialError describing the
+// failures. Errors that affect every language, such as an unplayable video,
+// are returned as is with no transcripts.
+func (c *Client) GetTranscripts(videoID string, languageCodes []string, opts ...CallOption) ([]*Transcript, error) {
+	call := newCallConfig(opts)
+	ctx := context.Background()
+	playerResponse, err := c.getPlayerResponse(ctx, videoID, call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player response: %w", err)
+	}
+	tracks := playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no transcripts available for this video")
+	}
+
+	fetched := make([]*Transcript, len(languageCodes))
+	errs := make([]error, len(languageCodes))
+	slots := make(chan struct{}, call.languageConcurrency)
+	var wg sync.WaitGroup
+	for i, languageCode := range languageCodes {
+		track, substitution, err := selectTrack(playerResponse, languageCode, call.languagePolicy)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			transcript, err := c.fetchVideoTranscript(ctx, videoID, playerResponse, track, call)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			recordSubstitution(transcript, languageCode, substitution)
+			fetched[i] = transcript
+		}()
+	}
+	wg.Wait()
+
+	var transcripts []*Transcript
+	var failures []LanguageFailure
+	for i, languageCode := range languageCodes {
+		if errs[i] != nil {
+			failures = append(failures, LanguageFailure{LanguageCode: languageCode, Err: errs[i]})
+			continue
+		}
+		transcripts = append(transcripts, fetched[i])
+	}
+
+	if len(failures) > 0 {
+		return transcripts, &PartialError{Requested: len(languageCodes), Failures: failures}
+	}
+	return transcripts, nil
+}
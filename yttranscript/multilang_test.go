@@ -0,0 +1,93 @@
+package yttranscript_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+// inFlightTransport delays timedtext requests and records the most that were
+// in flight at once.
+type inFlightTransport struct {
+	base http.RoundTripper
+
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (t *inFlightTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !strings.Contains(req.URL.Path, "timedtext") {
+		return t.base.RoundTrip(req)
+	}
+	t.mu.Lock()
+	t.inFlight++
+	t.max = max(t.max, t.inFlight)
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.inFlight--
+		t.mu.Unlock()
+	}()
+	time.Sleep(50 * time.Millisecond)
+	return t.base.RoundTrip(req)
+}
+
+func TestGetTranscripts(t *testing.T) {
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	s.SetTranslatedTimedText(yttranscripttest.FixtureVideoID, "en", "es", yttranscripttest.Fixture("timedtext_en_de.xml"))
+
+	tests := []struct {
+		name        string
+		concurrency int
+		wantMax     int
+	}{
+		{name: "sequential", concurrency: 1, wantMax: 1},
+		{name: "two at a time", concurrency: 2, wantMax: 2},
+	}
+	for _, tt := range tests {
+		transport := &inFlightTransport{base: s.Transport()}
+		client, err := yttranscript.New(yttranscript.WithTransport(transport))
+		if err != nil {
+			t.Fatal(err)
+		}
+		transcripts, err := client.GetTranscripts(yttranscripttest.FixtureVideoID, []string{"es", "fr", "en", "de"},
+			yttranscript.WithLanguageConcurrency(tt.concurrency),
+			yttranscript.WithLanguagePolicy(yttranscript.LanguageSubstituteTranslation))
+
+		var partial *yttranscript.PartialError
+		if !errors.As(err, &partial) || partial.Requested != 4 || len(partial.Failures) != 1 || partial.Failures[0].LanguageCode != "fr" {
+			t.Errorf("%s: err = %v, want a partial failure of fr", tt.name, err)
+		}
+		var codes []string
+		for _, transcript := range transcripts {
+			codes = append(codes, transcript.LanguageCode)
+		}
+		if strings.Join(codes, ",") != "es,en,de" {
+			t.Errorf("%s: languages = %q, want es,en,de in request order", tt.name, codes)
+		}
+		if transport.max != tt.wantMax {
+			t.Errorf("%s: %d downloads in flight at once, want %d", tt.name, transport.max, tt.wantMax)
+		}
+	}
+
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transcripts, err := client.GetTranscripts(yttranscripttest.FixtureVideoID, []string{"es", "en"})
+	var partial *yttranscript.PartialError
+	if !errors.As(err, &partial) || len(partial.Failures) != 1 || partial.Failures[0].LanguageCode != "es" || len(transcripts) != 1 {
+		t.Errorf("without a policy: got %d transcripts, err %v; want es to fail rather than be translated", len(transcripts), err)
+	}
+	if transcripts, err := client.GetTranscripts("missingVideo", []string{"en"}); err == nil || transcripts != nil {
+		t.Errorf("unplayable video: got %d transcripts, err %v", len(transcripts), err)
+	}
+}
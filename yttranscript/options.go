@@ -0,0 +1,61 @@
+package yttranscript
+
+// defaultCountry is the gl value sent when a call does not set one.
+const defaultCountry = "US"
+
+// CallOption configures a single request made by a Client method.
+type CallOption func(*callConfig)
+
+type callConfig struct {
+	country string
+	clean   CleanOptions
+
+	strictLanguage bool
+	languagePolicy LanguagePolicy
+	alternates     AlternateSources
+
+	languageConcurrency int
+}
+
+func newCallConfig(opts []CallOption) callConfig {
+	call := callConfig{country: defaultCountry, languagePolicy: LanguageFail, languageConcurrency: defaultLanguageConcurrency}
+	for _, opt := range opts {
+		opt(&call)
+	}
+	return call
+}
+
+// WithCountry sets the two-letter country code (InnerTube's gl parameter) the
+// request is made as, so the caption list is the one offered in that
+// country. It does not change the network location of the request.
+func WithCountry(gl string) CallOption {
+	return func(call *callConfig) {
+		call.country = gl
+	}
+}
+
+// WithLanguageCheck makes the call fail with a *LanguageMismatchError when the
+// captions delivered are detected to be in a different language than the
+// track claims, as happens with mislabeled ASR tracks. Without it a mismatch
+// only adds a warning to the transcript.
+func WithLanguageCheck() CallOption {
+	return func(call *callConfig) {
+		call.strictLanguage = true
+	}
+}
+
+// WithCleanOptions applies extra cleaning to the fetched transcript text on
+// top of the entity decoding and tag stripping always performed.
+func WithCleanOptions(clean CleanOptions) CallOption {
+	return func(call *callConfig) {
+		call.clean = clean
+	}
+}
+
+// WithLanguageConcurrency sets how many tracks GetTranscripts downloads at
+// once. Values below one download the tracks one after another.
+func WithLanguageConcurrency(n int) CallOption {
+	return func(call *callConfig) {
+		call.languageConcurrency = max(n, 1)
+	}
+}
@@ -0,0 +1,102 @@
+package yttranscript
+
+import (
+	"context"
+	"fmt"
+)
+
+// StreamingData lists the media formats of a video. Format URLs expire after
+// ExpiresInSeconds and are only usable from the network that requested them;
+// formats served to the WEB client usually carry a SignatureCipher instead of
+// a URL.
+type StreamingData struct {
+	ExpiresInSeconds string   `json:"expiresInSeconds"`
+	Formats          []Format `json:"formats"`         // Muxed audio and video.
+	AdaptiveFormats  []Format `json:"adaptiveFormats"` // Audio-only and video-only.
+	HLSManifestURL   string   `json:"hlsManifestUrl"`  // Set for live broadcasts.
+	DashManifestURL  string   `json:"dashManifestUrl"`
+}
+
+// Format is one media format of a video.
+type Format struct {
+	Itag             int    `json:"itag"`
+	URL              string `json:"url"`
+	SignatureCipher  string `json:"signatureCipher"`
+	MimeType         string `json:"mimeType"` // Includes the codecs, e.g. `video/mp4; codecs="avc1.4d401e"`.
+	Bitrate          int    `json:"bitrate"`
+	Width            int    `json:"width"`
+	Height           int    `json:"height"`
+	FPS              int    `json:"fps"`
+	Quality          string `json:"quality"`
+	QualityLabel     string `json:"qualityLabel"`
+	ContentLength    string `json:"contentLength"`
+	ApproxDurationMs string `json:"approxDurationMs"`
+	AudioQuality     string `json:"audioQuality"`
+	AudioSampleRate  string `json:"audioSampleRate"`
+	AudioChannels    int    `json:"audioChannels"`
+}
+
+// Thumbnail is one size of a video or channel image.
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Thumbnails is the list of sizes an image is offered in.
+type Thumbnails struct {
+	Thumbnails []Thumbnail `json:"thumbnails"`
+}
+
+// Microformat holds the metadata YouTube publishes about a video for search
+// engines.
+type Microformat struct {
+	PlayerMicroformatRenderer PlayerMicroformat `json:"playerMicroformatRenderer"`
+}
+
+// PlayerMicroformat is the microformat of a video.
+type PlayerMicroformat struct {
+	Title              FormattedText `json:"title"`
+	Description        FormattedText `json:"description"`
+	Thumbnail          Thumbnails    `json:"thumbnail"`
+	LengthSeconds      string        `json:"lengthSeconds"`
+	OwnerChannelName   string        `json:"ownerChannelName"`
+	ExternalChannelID  string        `json:"externalChannelId"`
+	OwnerProfileURL    string        `json:"ownerProfileUrl"`
+	ViewCount          string        `json:"viewCount"`
+	IsFamilySafe       bool          `json:"isFamilySafe"`
+	IsUnlisted         bool          `json:"isUnlisted"`
+	IsShortsEligible   bool          `json:"isShortsEligible"`
+	AvailableCountries []string      `json:"availableCountries"`
+	PublishDate        string        `json:"publishDate"`
+	UploadDate         string        `json:"uploadDate"`
+	Category           string        `json:"category"`
+
+	// LiveBroadcastDetails is set for live broadcasts, premieres and their
+	// replays.
+	LiveBroadcastDetails *struct {
+		IsLiveNow      bool   `json:"isLiveNow"`
+		StartTimestamp string `json:"startTimestamp"`
+		EndTimestamp   string `json:"endTimestamp"`
+	} `json:"liveBroadcastDetails"`
+}
+
+// GetPlayerResponse returns the decoded InnerTube player response for a
+// video, as used by the other methods, so that callers can build on its
+// streaming data, video details and microformat without another request.
+// The watch page scraping, client profile fallback and playability checks
+// are the same as for the other methods. Use GetPlayerResponseRaw for fields
+// PlayerResponse does not model.
+func (c *Client) GetPlayerResponse(videoID string, opts ...CallOption) (*PlayerResponse, error) {
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, newCallConfig(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player response: %w", err)
+	}
+	return playerResponse, nil
+}
+
+// Client returns the name of the InnerTube client profile the response was
+// requested as.
+func (r *PlayerResponse) Client() string {
+	return r.clientName
+}
@@ -0,0 +1,88 @@
+package yttranscript
+
+// ClientProfile describes the InnerTube client a player request identifies as.
+type ClientProfile struct {
+	Name      string                 // clientName sent in the request context.
+	Version   string                 // clientVersion sent in the request context.
+	UserAgent string                 // User-Agent header sent with the request.
+	Extra     map[string]interface{} // Additional client context fields.
+	EmbedURL  string                 // Page the player claims to be embedded in, if any.
+}
+
+// Known InnerTube client profiles.
+var (
+	ProfileWeb = ClientProfile{
+		Name:      "WEB",
+		Version:   "2.20250312.04.00",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Safari/537.36",
+	}
+	ProfileAndroid = ClientProfile{
+		Name:      "ANDROID",
+		Version:   "19.09.37",
+		UserAgent: "com.google.android.youtube/19.09.37 (Linux; U; Android 11) gzip",
+		Extra: map[string]interface{}{
+			"androidSdkVersion": 30,
+			"osName":            "Android",
+			"osVersion":         "11",
+		},
+	}
+	ProfileIOS = ClientProfile{
+		Name:      "IOS",
+		Version:   "19.45.4",
+		UserAgent: "com.google.ios.youtube/19.45.4 (iPhone16,2; U; CPU iOS 18_1_0 like Mac OS X;)",
+		Extra: map[string]interface{}{
+			"deviceMake":  "Apple",
+			"deviceModel": "iPhone16,2",
+			"osName":      "iPhone",
+			"osVersion":   "18.1.0.22B83",
+		},
+	}
+	ProfileTVHTML5 = ClientProfile{
+		Name:      "TVHTML5",
+		Version:   "7.20250312.16.00",
+		UserAgent: "Mozilla/5.0 (ChromiumStylePlatform) Cobalt/Version",
+	}
+	ProfileWebEmbedded = ClientProfile{
+		Name:      "WEB_EMBEDDED_PLAYER",
+		Version:   "1.20250310.01.00",
+		UserAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/134.0.0.0 Safari/537.36",
+	}
+	ProfileTVEmbedded = ClientProfile{
+		Name:      "TVHTML5_SIMPLY_EMBEDDED_PLAYER",
+		Version:   "2.0",
+		UserAgent: "Mozilla/5.0 (ChromiumStylePlatform) Cobalt/Version",
+		EmbedURL:  "https://www.youtube.com/",
+	}
+)
+
+// DefaultProfiles is the order in which client profiles are tried when no
+// other order is configured.
+var DefaultProfiles = []ClientProfile{
+	ProfileWeb,
+	ProfileAndroid,
+	ProfileIOS,
+	ProfileTVHTML5,
+	ProfileWebEmbedded,
+}
+
+// AgeGateProfiles are the embedded player clients retried when every
+// configured profile reports a video as age-restricted. Embedded players
+// are often served captions of such videos without a signed-in account.
+var AgeGateProfiles = []ClientProfile{
+	ProfileTVEmbedded,
+	embedded(ProfileWebEmbedded, "https://www.youtube.com/"),
+}
+
+// CaptionFallbackProfiles are tried, unless already configured, when every
+// playable player response came without caption tracks. The mobile clients
+// are sometimes sent tracks the WEB client is not.
+var CaptionFallbackProfiles = []ClientProfile{
+	ProfileAndroid,
+	ProfileIOS,
+}
+
+// embedded returns profile claiming to be embedded in embedURL.
+func embedded(profile ClientProfile, embedURL string) ClientProfile {
+	profile.EmbedURL = embedURL
+	return profile
+}
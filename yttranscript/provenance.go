@@ -0,0 +1,45 @@
+package yttranscript
+
+import "time"
+
+// Version identifies this library in provenance records. Release builds set
+// it with -ldflags "-X yt-transcript/yttranscript.Version=<version>".
+var Version = "dev"
+
+// Provenance records where a transcript came from and when it was fetched.
+type Provenance struct {
+	VideoID        string    `json:"video_id"`
+	TrackID        string    `json:"track_id"`                  // The track's vssId, e.g. "a.en".
+	TrackName      string    `json:"track_name"`                // Name the track is listed under on YouTube.
+	LanguageCode   string    `json:"language_code"`             // Language of the delivered text.
+	Kind           string    `json:"kind,omitempty"`            // "asr" for automatic captions.
+	TranslatedFrom string    `json:"translated_from,omitempty"` // Source language if machine translated.
+	FetchedAt      time.Time `json:"fetched_at"`
+	ToolVersion    string    `json:"tool_version"`
+
+	// RequestedLanguage and Substitution are set when the requested language
+	// was missing and another track was delivered under a LanguagePolicy.
+	RequestedLanguage string         `json:"requested_language,omitempty"`
+	Substitution      LanguagePolicy `json:"substitution,omitempty"`
+
+	// AlternateFor is the requested video when it was unavailable and the
+	// transcript was taken from a duplicate under WithAlternateSources.
+	AlternateFor string `json:"alternate_for,omitempty"`
+
+	// Client is the InnerTube client profile whose player response listed
+	// the track, or empty if the track was found without one.
+	Client string `json:"client,omitempty"`
+}
+
+func newProvenance(videoID string, track CaptionTrack) Provenance {
+	return Provenance{
+		VideoID:        videoID,
+		TrackID:        track.VssID,
+		TrackName:      track.Name.SimpleText,
+		LanguageCode:   track.LanguageCode,
+		Kind:           track.Kind,
+		TranslatedFrom: track.translatedFrom,
+		FetchedAt:      time.Now().UTC(),
+		ToolVersion:    Version,
+	}
+}
@@ -0,0 +1,146 @@
+package yttranscript
+
+import (
+	"sort"
+	"strings"
+)
+
+// QuoteMatch is a passage of a transcript resembling a quote.
+type QuoteMatch struct {
+	VideoID string
+	Start   float64 // Start time of the passage in seconds.
+	End     float64 // End time of the passage in seconds.
+	Text    string  // The passage as it appears in the transcript.
+	Score   float64 // Similarity to the quote, from 0 to 1 for an exact match.
+}
+
+// Link returns a link that opens the video at the start of the passage.
+func (m QuoteMatch) Link() string {
+	return DeepLink(m.VideoID, m.Start)
+}
+
+// quoteWord is a transcript word together with its normalized form.
+type quoteWord struct {
+	Word
+	key string
+}
+
+// FindQuote searches the transcript for passages resembling quote and returns
+// those scoring at least minScore, best first and without overlaps. Words are
+// compared ignoring case and punctuation, and the score is one minus the word
+// edit distance between quote and passage relative to the longer of the two,
+// so paraphrases with a few words added, dropped or changed still match.
+// Passage times are interpolated within segments, see Text.Words.
+func (t *Transcript) FindQuote(quote string, minScore float64) []QuoteMatch {
+	var quoteKeys []string
+	for _, field := range strings.Fields(quote) {
+		if key := quoteKey(field); key != "" {
+			quoteKeys = append(quoteKeys, key)
+		}
+	}
+	if len(quoteKeys) == 0 {
+		return nil
+	}
+
+	var words []quoteWord
+	for _, text := range t.Texts {
+		for _, word := range text.Words(nil) {
+			if key := quoteKey(word.Text); key != "" {
+				words = append(words, quoteWord{Word: word, key: key})
+			}
+		}
+	}
+
+	// Passages may be up to a quarter shorter or longer than the quote.
+	slack := len(quoteKeys) / 4
+	minLen := max(len(quoteKeys)-slack, 1)
+	maxLen := len(quoteKeys) + slack
+
+	type candidate struct {
+		from, to int
+		score    float64
+	}
+	var candidates []candidate
+	for from := range words {
+		distances := prefixEditDistances(quoteKeys, words[from:min(from+maxLen, len(words))])
+		best := candidate{score: -1}
+		for n := minLen; n < len(distances); n++ {
+			score := 1 - distances[n]/float64(max(n, len(quoteKeys)))
+			if score > best.score {
+				best = candidate{from: from, to: from + n, score: score}
+			}
+		}
+		if best.score >= minScore && best.score > 0 {
+			candidates = append(candidates, best)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score > candidates[j].score })
+
+	var matches []QuoteMatch
+	taken := make([]bool, len(words))
+	for _, c := range candidates {
+		overlaps := false
+		for i := c.from; i < c.to; i++ {
+			overlaps = overlaps || taken[i]
+		}
+		if overlaps {
+			continue
+		}
+		parts := make([]string, 0, c.to-c.from)
+		for i := c.from; i < c.to; i++ {
+			taken[i] = true
+			parts = append(parts, words[i].Text)
+		}
+		matches = append(matches, QuoteMatch{
+			VideoID: t.VideoID,
+			Start:   words[c.from].Start,
+			End:     words[c.to-1].End,
+			Text:    strings.Join(parts, " "),
+			Score:   c.score,
+		})
+	}
+	return matches
+}
+
+// quoteKey normalizes a word for comparison, dropping case and punctuation
+// so that "commitment's" and "commitments" compare equal.
+func quoteKey(word string) string {
+	return strings.ReplaceAll(normalizeForDiff(word), " ", "")
+}
+
+// prefixEditDistances returns, for every n up to len(words), the word edit
+// distance between quote and the first n words.
+func prefixEditDistances(quote []string, words []quoteWord) []float64 {
+	// column[i] is the distance between quote[:i] and the current prefix.
+	column := make([]float64, len(quote)+1)
+	for i := range column {
+		column[i] = float64(i)
+	}
+	distances := make([]float64, len(words)+1)
+	distances[0] = column[len(quote)]
+
+	for n, word := range words {
+		diagonal := column[0]
+		column[0] = float64(n + 1)
+		for i, key := range quote {
+			next := min(column[i+1]+1, column[i]+1, diagonal+substitutionCost(key, word.key))
+			diagonal = column[i+1]
+			column[i+1] = next
+		}
+		distances[n+1] = column[len(quote)]
+	}
+	return distances
+}
+
+// substitutionCost is the cost of replacing word a with b: nothing for the
+// same word, half for inflections of one another such as "commitment" and
+// "commitments", and one otherwise.
+func substitutionCost(a, b string) float64 {
+	switch {
+	case a == b:
+		return 0
+	case min(len(a), len(b)) >= 4 && (strings.HasPrefix(a, b) || strings.HasPrefix(b, a)):
+		return 0.5
+	}
+	return 1
+}
@@ -0,0 +1,85 @@
+package yttranscript_test
+
+import (
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestFindQuote(t *testing.T) {
+	transcript := &yttranscript.Transcript{Texts: []yttranscript.Text{
+		{Start: 0, Duration: 4, Content: "A full commitment's what I'm thinking of"},
+		{Start: 4, Duration: 4, Content: "You wouldn't get this from any other guy"},
+		{Start: 8, Duration: 2, Content: "Never gonna give you up"},
+		{Start: 10, Duration: 2, Content: "Never gonna let you down"},
+	}}
+	tests := []struct {
+		name     string
+		quote    string
+		minScore float64
+		want     []string
+		minFirst float64 // Lowest acceptable score of the first match.
+	}{
+		{
+			name:     "exact",
+			quote:    "a full commitment's what I'm thinking of",
+			minScore: 1,
+			want:     []string{"A full commitment's what I'm thinking of"},
+			minFirst: 1,
+		},
+		{
+			name:     "paraphrase",
+			quote:    "full commitments, what I'm thinking",
+			minScore: 0.7,
+			want:     []string{"full commitment's what I'm thinking"},
+			minFirst: 0.85,
+		},
+		{
+			name:     "across segments",
+			quote:    "thinking of you wouldn't get this",
+			minScore: 1,
+			want:     []string{"thinking of You wouldn't get this"},
+			minFirst: 1,
+		},
+		{
+			name:     "several without overlap",
+			quote:    "never gonna",
+			minScore: 1,
+			want:     []string{"Never gonna", "Never gonna"},
+			minFirst: 1,
+		},
+		{
+			name:     "no match",
+			quote:    "completely unrelated words here",
+			minScore: 0.5,
+		},
+		{
+			name:     "empty quote",
+			quote:    " ... ",
+			minScore: 0,
+		},
+	}
+	for _, tt := range tests {
+		matches := transcript.FindQuote(tt.quote, tt.minScore)
+		if len(matches) != len(tt.want) {
+			t.Errorf("%s: got %+v, want %q", tt.name, matches, tt.want)
+			continue
+		}
+		for i, m := range matches {
+			if m.Text != tt.want[i] {
+				t.Errorf("%s: match %d = %q, want %q", tt.name, i, m.Text, tt.want[i])
+			}
+			if m.Score < tt.minScore || m.Score > 1 || m.Start >= m.End {
+				t.Errorf("%s: match %d = %+v out of range", tt.name, i, m)
+			}
+		}
+		if len(matches) > 0 && matches[0].Score < tt.minFirst {
+			t.Errorf("%s: best score %v, want at least %v", tt.name, matches[0].Score, tt.minFirst)
+		}
+	}
+
+	matches := transcript.FindQuote("thinking of you wouldn't get this", 1)
+	if len(matches) == 1 && (matches[0].Start <= 0 || matches[0].Start >= 4 || matches[0].End <= 4 || matches[0].End >= 8) {
+		t.Errorf("passage across segments spans %v to %v, want interpolated times around 4", matches[0].Start, matches[0].End)
+	}
+}
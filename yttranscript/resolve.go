@@ -0,0 +1,134 @@
+package yttranscript
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MinTitleScore is the score below which ResolveVideo does not accept a
+// title as matching.
+const MinTitleScore = 0.5
+
+// ErrNoMatchingVideo is returned by ResolveVideo when no video of the channel
+// has a title resembling the one given.
+var ErrNoMatchingVideo = errors.New("no matching video found")
+
+// channelIDRegex matches channel IDs, which are used in URLs instead of
+// handles.
+var channelIDRegex = regexp.MustCompile(`^UC[A-Za-z0-9_-]{22}$`)
+
+// videoRendererRegex matches the ID and title of each video result in the
+// initial data embedded in a channel search page.
+var videoRendererRegex = regexp.MustCompile(`"videoRenderer":\{"videoId":"([A-Za-z0-9_-]{11})".*?"title":\{"runs":\[\{"text":("(?:[^"\\]|\\.)*")`)
+
+// TitleMatch is a video whose title resembles a searched title.
+type TitleMatch struct {
+	VideoID string
+	Title   string
+	Score   float64 // Share of the searched words found in the title, from 0 to 1.
+}
+
+// SearchChannelVideos searches a channel's videos for title and returns the
+// results, best matching first. The channel is given by handle ("@name"),
+// channel ID or channel URL. Scores ignore case and punctuation, and extra
+// words in a video's title only break ties, so a title quoted from memory
+// or in part still scores high.
+func (c *Client) SearchChannelVideos(channel, title string) ([]TitleMatch, error) {
+	channelPath, err := channelPath(channel)
+	if err != nil {
+		return nil, err
+	}
+	htmlContent, err := c.fetchURL(youtubeURL + channelPath + "/search?query=" + url.QueryEscape(title))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch channel search: %w", err)
+	}
+
+	var matches []TitleMatch
+	seen := make(map[string]bool)
+	for _, found := range videoRendererRegex.FindAllStringSubmatch(htmlContent, -1) {
+		var videoTitle string
+		if seen[found[1]] || json.Unmarshal([]byte(found[2]), &videoTitle) != nil {
+			continue
+		}
+		seen[found[1]] = true
+		matches = append(matches, TitleMatch{VideoID: found[1], Title: videoTitle, Score: titleScore(title, videoTitle)})
+	}
+	c.logger.Debug("searched channel", "channel", channel, "query", title, "results", len(matches))
+
+	// YouTube's order breaks ties between titles of the same length.
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return len(strings.Fields(matches[i].Title)) < len(strings.Fields(matches[j].Title))
+	})
+	return matches, nil
+}
+
+// ResolveVideo returns the ID of the channel's video whose title best
+// matches title, as found by SearchChannelVideos. It fails with
+// ErrNoMatchingVideo if no title scores at least MinTitleScore.
+func (c *Client) ResolveVideo(channel, title string) (string, error) {
+	matches, err := c.SearchChannelVideos(channel, title)
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 || matches[0].Score < MinTitleScore {
+		return "", fmt.Errorf("%w for %q in %s", ErrNoMatchingVideo, title, channel)
+	}
+	return matches[0].VideoID, nil
+}
+
+// channelPath returns the URL path of a channel's page.
+func channelPath(channel string) (string, error) {
+	channel = strings.TrimSpace(channel)
+	if u, err := url.Parse(channel); err == nil && u.Host != "" {
+		channel = strings.Trim(u.Path, "/")
+		if rest, ok := strings.CutPrefix(channel, "channel/"); ok {
+			channel = rest
+		}
+		channel, _, _ = strings.Cut(channel, "/")
+	}
+	switch {
+	case channelIDRegex.MatchString(channel):
+		return "/channel/" + channel, nil
+	case strings.HasPrefix(channel, "@") && len(channel) > 1:
+		return "/" + url.PathEscape(channel), nil
+	case channel != "" && !strings.ContainsAny(channel, "/?# "):
+		return "/@" + url.PathEscape(channel), nil
+	}
+	return "", fmt.Errorf("invalid channel %q", channel)
+}
+
+// titleScore is the share of the words of query found in title, with
+// inflections counting half as in FindQuote.
+func titleScore(query, title string) float64 {
+	var titleKeys []string
+	for _, word := range strings.Fields(title) {
+		if key := quoteKey(word); key != "" {
+			titleKeys = append(titleKeys, key)
+		}
+	}
+	var words, found float64
+	for _, word := range strings.Fields(query) {
+		key := quoteKey(word)
+		if key == "" {
+			continue
+		}
+		words++
+		cost := 1.0
+		for _, titleKey := range titleKeys {
+			cost = min(cost, substitutionCost(key, titleKey))
+		}
+		found += 1 - cost
+	}
+	if words == 0 {
+		return 0
+	}
+	return found / words
+}
@@ -0,0 +1,175 @@
+package yttranscript
+
+import (
+	"strings"
+)
+
+// CompareScript aligns an intended script with what the transcript says and
+// reports the deviations: script passages that were not said (ChangeRemoved),
+// passages said but not in the script (ChangeAdded) and passages said
+// differently (ChangeModified). Before holds the script's wording and After
+// what was said. Words are compared ignoring case and punctuation. Omitted
+// passages are placed at the time the next spoken word starts.
+func (t *Transcript) CompareScript(script string) []Change {
+	var scriptWords []string
+	var scriptKeys []string
+	for _, field := range strings.Fields(script) {
+		if key := quoteKey(field); key != "" {
+			scriptWords = append(scriptWords, field)
+			scriptKeys = append(scriptKeys, key)
+		}
+	}
+
+	var spoken []quoteWord
+	for _, text := range t.Texts {
+		for _, word := range text.Words(nil) {
+			if key := quoteKey(word.Text); key != "" {
+				spoken = append(spoken, quoteWord{Word: word, key: key})
+			}
+		}
+	}
+	spokenKeys := make([]string, len(spoken))
+	for i, word := range spoken {
+		spokenKeys[i] = word.key
+	}
+
+	var changes []Change
+	ops := diffWords(scriptKeys, spokenKeys)
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			i++
+			continue
+		}
+		// Collect the run of edits between two matching words.
+		var before, after []string
+		var firstSpoken, lastSpoken = -1, -1
+		nextSpoken := 0
+		for ; i < len(ops) && ops[i].kind != opEqual; i++ {
+			switch ops[i].kind {
+			case opDelete:
+				before = append(before, scriptWords[ops[i].a])
+			case opInsert:
+				if firstSpoken < 0 {
+					firstSpoken = ops[i].b
+				}
+				lastSpoken = ops[i].b
+				after = append(after, spoken[ops[i].b].Text)
+			}
+		}
+		if i < len(ops) {
+			nextSpoken = ops[i].b
+		} else {
+			nextSpoken = len(spoken)
+		}
+
+		change := Change{Before: strings.Join(before, " "), After: strings.Join(after, " ")}
+		switch {
+		case len(after) == 0:
+			change.Kind = ChangeRemoved
+			change.Start = spokenTime(spoken, nextSpoken)
+			change.End = change.Start
+		case len(before) == 0:
+			change.Kind = ChangeAdded
+		default:
+			change.Kind = ChangeModified
+		}
+		if len(after) > 0 {
+			change.Start = spoken[firstSpoken].Start
+			change.End = spoken[lastSpoken].End
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// spokenTime returns the start of the i-th spoken word, or the end of the
+// last one if i is past the end.
+func spokenTime(spoken []quoteWord, i int) float64 {
+	if i < len(spoken) {
+		return spoken[i].Start
+	}
+	if len(spoken) > 0 {
+		return spoken[len(spoken)-1].End
+	}
+	return 0
+}
+
+type diffOpKind int
+
+const (
+	opEqual diffOpKind = iota
+	opDelete
+	opInsert
+)
+
+// diffOp is one step of an edit script turning a into b. a and b index the
+// word consumed from each side.
+type diffOp struct {
+	kind diffOpKind
+	a, b int
+}
+
+// diffWords returns the shortest edit script turning a into b, using Myers'
+// O(ND) algorithm so that mostly matching inputs stay cheap. Only the
+// reachable diagonals of each step are kept, so memory is O(D²).
+func diffWords(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	limit := n + m
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	var x, y int
+search:
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y = x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var ops []diffOp
+	for d := len(trace) - 1; d >= 0; d-- {
+		prev := func(k int) int { return trace[d][k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && prev(k-1) < prev(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: opEqual, a: x, b: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{kind: opInsert, a: prevX, b: prevY})
+			} else {
+				ops = append(ops, diffOp{kind: opDelete, a: prevX, b: prevY})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
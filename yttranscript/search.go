@@ -0,0 +1,91 @@
+package yttranscript
+
+import (
+	"sort"
+	"strings"
+)
+
+// searchContextSegments is the number of neighbouring segments included on
+// each side of a match.
+const searchContextSegments = 1
+
+// Match is a place in a transcript where a search query occurs. A match may
+// run across several segments, as a phrase is often split between caption
+// lines.
+type Match struct {
+	VideoID string
+	Index   int     // Index in Transcript.Texts of the segment the match begins in.
+	Start   float64 // Start time of that segment in seconds.
+	Text    string  // Content of the segments the match spans.
+	Context string  // Those segments joined with their neighbours.
+}
+
+// Search returns every place the transcript contains query, ignoring case
+// and differences in whitespace, including across segment boundaries. Each
+// segment begins at most one match.
+func (t *Transcript) Search(query string) []Match {
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if query == "" {
+		return nil
+	}
+
+	// Search the segments joined by spaces, with offsets[i] the position in
+	// text at which segments[i] begins.
+	var text strings.Builder
+	var segments, offsets []int
+	for i, segment := range t.Texts {
+		content := strings.Join(strings.Fields(strings.ToLower(segment.Content)), " ")
+		if content == "" {
+			continue
+		}
+		if text.Len() > 0 {
+			text.WriteByte(' ')
+		}
+		segments, offsets = append(segments, i), append(offsets, text.Len())
+		text.WriteString(content)
+	}
+	joined := text.String()
+	// segmentAt returns the position in segments of the segment holding the
+	// byte at pos of joined.
+	segmentAt := func(pos int) int {
+		return sort.Search(len(offsets), func(k int) bool { return offsets[k] > pos }) - 1
+	}
+
+	var matches []Match
+	for from := 0; from < len(joined); {
+		pos := strings.Index(joined[from:], query)
+		if pos < 0 {
+			break
+		}
+		pos += from
+		first, last := segmentAt(pos), segmentAt(pos+len(query)-1)
+		matches = append(matches, Match{
+			VideoID: t.VideoID,
+			Index:   segments[first],
+			Start:   t.Texts[segments[first]].Start,
+			Text:    t.joinContent(segments[first], segments[last]+1),
+			Context: t.joinContent(segments[first]-searchContextSegments, segments[last]+searchContextSegments+1),
+		})
+		if first+1 == len(offsets) {
+			break
+		}
+		from = offsets[first+1]
+	}
+	return matches
+}
+
+// Link returns a link that opens the video at the matching segment.
+func (m Match) Link() string {
+	return DeepLink(m.VideoID, m.Start)
+}
+
+// joinContent joins the content of t.Texts[from:to], clamped to the
+// transcript.
+func (t *Transcript) joinContent(from, to int) string {
+	from, to = max(from, 0), min(to, len(t.Texts))
+	parts := make([]string, 0, to-from)
+	for _, text := range t.Texts[from:to] {
+		parts = append(parts, text.Content)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,96 @@
+package yttranscript_test
+
+import (
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestSearch(t *testing.T) {
+	transcript := &yttranscript.Transcript{VideoID: "dQw4w9WgXcQ", Texts: []yttranscript.Text{
+		{Start: 0, Duration: 2, Content: "We're no strangers to love"},
+		{Start: 2, Duration: 2, Content: "Never gonna give you up"},
+		{Start: 4, Duration: 2, Content: "Never gonna let you down"},
+		{Start: 6, Duration: 2, Content: "Never gonna run around"},
+	}}
+	tests := []struct {
+		query       string
+		wantIndexes []int
+	}{
+		{query: "never gonna", wantIndexes: []int{1, 2, 3}},
+		{query: "  NEVER GONNA GIVE ", wantIndexes: []int{1}},
+		{query: "strangers", wantIndexes: []int{0}},
+		{query: "you", wantIndexes: []int{1, 2}},
+		{query: "give  you\tup", wantIndexes: []int{1}},
+		{query: "to love never", wantIndexes: []int{0}},
+		{query: "up never gonna let you down never", wantIndexes: []int{1}},
+		{query: "no such phrase"},
+		{query: ""},
+	}
+	for _, tt := range tests {
+		matches := transcript.Search(tt.query)
+		var indexes []int
+		for _, m := range matches {
+			indexes = append(indexes, m.Index)
+			if m.VideoID != transcript.VideoID || m.Start != transcript.Texts[m.Index].Start || !strings.HasPrefix(m.Text, transcript.Texts[m.Index].Content) {
+				t.Errorf("Search(%q) match %+v does not describe segment %d", tt.query, m, m.Index)
+			}
+		}
+		if len(indexes) != len(tt.wantIndexes) {
+			t.Errorf("Search(%q) indexes = %v, want %v", tt.query, indexes, tt.wantIndexes)
+			continue
+		}
+		for i := range indexes {
+			if indexes[i] != tt.wantIndexes[i] {
+				t.Errorf("Search(%q) indexes = %v, want %v", tt.query, indexes, tt.wantIndexes)
+				break
+			}
+		}
+	}
+
+	contexts := []struct {
+		query    string
+		wantText string
+		want     string
+	}{
+		{
+			query:    "strangers",
+			wantText: "We're no strangers to love",
+			want:     "We're no strangers to love Never gonna give you up",
+		},
+		{
+			query:    "let you down",
+			wantText: "Never gonna let you down",
+			want:     "Never gonna give you up Never gonna let you down Never gonna run around",
+		},
+		{
+			query:    "run around",
+			wantText: "Never gonna run around",
+			want:     "Never gonna let you down Never gonna run around",
+		},
+		{
+			query:    "to love never gonna",
+			wantText: "We're no strangers to love Never gonna give you up",
+			want:     "We're no strangers to love Never gonna give you up Never gonna let you down",
+		},
+	}
+	for _, tt := range contexts {
+		matches := transcript.Search(tt.query)
+		if len(matches) != 1 {
+			t.Errorf("Search(%q) = %d matches, want 1", tt.query, len(matches))
+			continue
+		}
+		if matches[0].Text != tt.wantText {
+			t.Errorf("Search(%q) Text = %q, want %q", tt.query, matches[0].Text, tt.wantText)
+		}
+		if matches[0].Context != tt.want {
+			t.Errorf("Search(%q) Context = %q, want %q", tt.query, matches[0].Context, tt.want)
+		}
+	}
+
+	matches := transcript.Search("let you down")
+	if want := "https://youtu.be/dQw4w9WgXcQ?t=4"; len(matches) == 1 && matches[0].Link() != want {
+		t.Errorf("Link() = %q, want %q", matches[0].Link(), want)
+	}
+}
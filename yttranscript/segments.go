@@ -0,0 +1,267 @@
+package yttranscript
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Defaults of SegmentOptions.
+const (
+	defaultBlockWords      = 20
+	defaultWindowBlocks    = 6
+	defaultMinSection      = time.Minute
+	defaultSectionTerms    = 3
+	minSectionTermRunes    = 3
+	minTilingBlocksPerSide = 2
+
+	// minTilingDepth is the least depth of a section break, so that a
+	// transcript about one topic is not split at chance dips in similarity.
+	minTilingDepth = 0.2
+)
+
+// SegmentOptions configures Segments.
+type SegmentOptions struct {
+	// BlockWords is the size, in words, of the blocks whose vocabulary is
+	// compared. Blocks are made of whole segments. Defaults to 20.
+	BlockWords int
+	// WindowBlocks is how many blocks on each side of a candidate boundary
+	// are compared. Defaults to 6.
+	WindowBlocks int
+	// MinSection is the shortest section produced. Defaults to one minute.
+	MinSection time.Duration
+}
+
+// Section is a stretch of a transcript about one topic. Times are in seconds.
+type Section struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Texts []Text  `json:"-"`
+	// Terms are the words most characteristic of the section, most
+	// characteristic first.
+	Terms []string `json:"terms"`
+}
+
+// Segments splits the transcript into topical sections with TextTiling: the
+// vocabulary of the blocks before and after every block boundary is
+// compared, and boundaries where it changes markedly more than usual become
+// section breaks. Words are weighted by how few blocks of the transcript
+// contain them, so function words in any language count for little. A
+// transcript too short to compare windows of blocks is returned as a single
+// section.
+func (t *Transcript) Segments(opts SegmentOptions) []Section {
+	if opts.BlockWords <= 0 {
+		opts.BlockWords = defaultBlockWords
+	}
+	if opts.WindowBlocks <= 0 {
+		opts.WindowBlocks = defaultWindowBlocks
+	}
+	if opts.MinSection <= 0 {
+		opts.MinSection = defaultMinSection
+	}
+	if len(t.Texts) == 0 {
+		return nil
+	}
+
+	blocks := tilingBlocks(t.Texts, opts.BlockWords)
+	weights := blockWeights(blocks)
+
+	var breaks []int // Indexes into t.Texts of the first segment of each section but the first.
+	if len(blocks) >= 2*minTilingBlocksPerSide {
+		scores := make([]float64, len(blocks)) // scores[i] compares the blocks before and from block i.
+		for i := 1; i < len(blocks); i++ {
+			before := blocks[max(0, i-opts.WindowBlocks):i]
+			after := blocks[i:min(len(blocks), i+opts.WindowBlocks)]
+			scores[i] = cosine(blockVector(before, weights), blockVector(after, weights))
+		}
+		breaks = tilingBreaks(t.Texts, blocks, smooth(scores), opts.MinSection.Seconds())
+	}
+
+	var sections []Section
+	from := 0
+	for _, to := range append(breaks, len(t.Texts)) {
+		sections = append(sections, newSection(t.Texts[from:to]))
+		from = to
+	}
+	sectionTerms(sections, weights)
+	return sections
+}
+
+// tilingBlock is a run of consecutive segments and the words they contain.
+type tilingBlock struct {
+	first int // Index of the block's first segment.
+	words map[string]int
+}
+
+// tilingBlocks groups texts into blocks of at least blockWords words.
+func tilingBlocks(texts []Text, blockWords int) []tilingBlock {
+	var blocks []tilingBlock
+	current := tilingBlock{words: make(map[string]int)}
+	count := 0
+	for i, text := range texts {
+		for _, word := range splitWords(strings.ToLower(text.Content)) {
+			if len([]rune(word)) > 1 {
+				current.words[word]++
+				count++
+			}
+		}
+		if count >= blockWords {
+			blocks = append(blocks, current)
+			current = tilingBlock{first: i + 1, words: make(map[string]int)}
+			count = 0
+		}
+	}
+	if count > 0 {
+		blocks = append(blocks, current)
+	}
+	return blocks
+}
+
+// blockWeights returns the inverse block frequency of every word.
+func blockWeights(blocks []tilingBlock) map[string]float64 {
+	frequency := make(map[string]int)
+	for _, block := range blocks {
+		for word := range block.words {
+			frequency[word]++
+		}
+	}
+	weights := make(map[string]float64, len(frequency))
+	for word, f := range frequency {
+		weights[word] = math.Log(float64(len(blocks)) / float64(f))
+	}
+	return weights
+}
+
+// blockVector returns the weighted word counts of blocks taken together.
+func blockVector(blocks []tilingBlock, weights map[string]float64) map[string]float64 {
+	vector := make(map[string]float64)
+	for _, block := range blocks {
+		for word, count := range block.words {
+			vector[word] += float64(count) * weights[word]
+		}
+	}
+	return vector
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for word, x := range a {
+		dot += x * b[word]
+		normA += x * x
+	}
+	for _, y := range b {
+		normB += y * y
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
+
+// smooth averages every score with its neighbours, as TextTiling does, so
+// that chance dips in the vocabulary of one block do not read as breaks.
+// scores[0] is unused.
+func smooth(scores []float64) []float64 {
+	smoothed := make([]float64, len(scores))
+	for i := 1; i < len(scores); i++ {
+		sum, n := 0.0, 0
+		for j := max(1, i-1); j <= min(len(scores)-1, i+1); j++ {
+			sum += scores[j]
+			n++
+		}
+		smoothed[i] = sum / float64(n)
+	}
+	return smoothed
+}
+
+// tilingBreaks picks section breaks among the block boundaries. A
+// boundary's depth is how far its similarity score lies below the highest
+// scores on either side. Of the boundaries scoring lower than both
+// neighbours, those deeper than both minTilingDepth and their mean depth
+// less half a standard deviation qualify, deepest first, as long as every
+// section lasts at least minSeconds.
+func tilingBreaks(texts []Text, blocks []tilingBlock, scores []float64, minSeconds float64) []int {
+	depths := make([]float64, len(blocks))
+	var candidates []int
+	for i := 1; i < len(blocks); i++ {
+		left, right := scores[i], scores[i]
+		for j := i - 1; j >= 1 && scores[j] >= left; j-- {
+			left = scores[j]
+		}
+		for j := i + 1; j < len(blocks) && scores[j] >= right; j++ {
+			right = scores[j]
+		}
+		depths[i] = left - scores[i] + right - scores[i]
+		if (i == 1 || scores[i] < scores[i-1]) && (i == len(blocks)-1 || scores[i] < scores[i+1]) {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var mean, variance float64
+	for _, i := range candidates {
+		mean += depths[i]
+	}
+	mean /= float64(len(candidates))
+	for _, i := range candidates {
+		variance += (depths[i] - mean) * (depths[i] - mean)
+	}
+	cutoff := mean - math.Sqrt(variance/float64(len(candidates)))/2
+
+	sort.SliceStable(candidates, func(a, b int) bool { return depths[candidates[a]] > depths[candidates[b]] })
+	start, end := texts[0].Start, texts[len(texts)-1].End()
+	var breaks []int
+	for _, i := range candidates {
+		if depths[i] < max(cutoff, minTilingDepth) {
+			break
+		}
+		at := texts[blocks[i].first].Start
+		fits := at-start >= minSeconds && end-at >= minSeconds
+		for _, b := range breaks {
+			fits = fits && math.Abs(texts[b].Start-at) >= minSeconds
+		}
+		if fits {
+			breaks = append(breaks, blocks[i].first)
+		}
+	}
+	sort.Ints(breaks)
+	return breaks
+}
+
+func newSection(texts []Text) Section {
+	section := Section{Start: texts[0].Start, Texts: texts}
+	for _, text := range texts {
+		section.End = max(section.End, text.End())
+	}
+	return section
+}
+
+// sectionTerms sets the terms of each section to its words with the highest
+// count times weight, skipping short words.
+func sectionTerms(sections []Section, weights map[string]float64) {
+	for i := range sections {
+		counts := make(map[string]int)
+		for _, text := range sections[i].Texts {
+			for _, word := range splitWords(strings.ToLower(text.Content)) {
+				if len([]rune(word)) >= minSectionTermRunes {
+					counts[word]++
+				}
+			}
+		}
+		terms := make([]string, 0, len(counts))
+		for word := range counts {
+			terms = append(terms, word)
+		}
+		score := func(word string) float64 { return float64(counts[word]) * weights[word] }
+		sort.Slice(terms, func(a, b int) bool {
+			if score(terms[a]) != score(terms[b]) {
+				return score(terms[a]) > score(terms[b])
+			}
+			return terms[a] < terms[b]
+		})
+		sections[i].Terms = terms[:min(defaultSectionTerms, len(terms))]
+	}
+}
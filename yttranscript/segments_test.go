@@ -0,0 +1,102 @@
+package yttranscript_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+var (
+	bakingWords    = strings.Fields("flour butter sugar oven dough bake knead yeast salt water")
+	astronomyWords = strings.Fields("star planet orbit galaxy comet telescope moon nebula light gravity")
+)
+
+// topicTexts returns n ten-second segments, each using every word of vocab
+// in a rotated order, starting at start seconds.
+func topicTexts(vocab []string, n int, start float64) []yttranscript.Text {
+	texts := make([]yttranscript.Text, n)
+	for i := range texts {
+		rotated := append(append([]string(nil), vocab[i%len(vocab):]...), vocab[:i%len(vocab)]...)
+		texts[i] = yttranscript.Text{Start: start + float64(i)*10, Duration: 10, Content: strings.Join(rotated, " ")}
+	}
+	return texts
+}
+
+func TestSegments(t *testing.T) {
+	twoTopics := append(topicTexts(bakingWords, 12, 0), topicTexts(astronomyWords, 12, 120)...)
+	tests := []struct {
+		name       string
+		texts      []yttranscript.Text
+		opts       yttranscript.SegmentOptions
+		wantStarts []float64
+	}{
+		{
+			name:       "topic change",
+			texts:      twoTopics,
+			opts:       yttranscript.SegmentOptions{BlockWords: 10, MinSection: 30 * time.Second},
+			wantStarts: []float64{0, 120},
+		},
+		{
+			name:       "one topic",
+			texts:      topicTexts(bakingWords, 24, 0),
+			opts:       yttranscript.SegmentOptions{BlockWords: 10, MinSection: 30 * time.Second},
+			wantStarts: []float64{0},
+		},
+		{
+			name:       "sections would be too short",
+			texts:      twoTopics,
+			opts:       yttranscript.SegmentOptions{BlockWords: 10, MinSection: 3 * time.Minute},
+			wantStarts: []float64{0},
+		},
+		{
+			name:       "too short to compare",
+			texts:      topicTexts(bakingWords, 3, 0),
+			wantStarts: []float64{0},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		sections := (&yttranscript.Transcript{Texts: tt.texts}).Segments(tt.opts)
+		var starts []float64
+		total := 0
+		for _, section := range sections {
+			starts = append(starts, section.Start)
+			total += len(section.Texts)
+		}
+		if len(starts) != len(tt.wantStarts) {
+			t.Errorf("%s: section starts = %v, want %v", tt.name, starts, tt.wantStarts)
+			continue
+		}
+		for i := range starts {
+			if starts[i] != tt.wantStarts[i] {
+				t.Errorf("%s: section starts = %v, want %v", tt.name, starts, tt.wantStarts)
+				break
+			}
+		}
+		if total != len(tt.texts) {
+			t.Errorf("%s: sections hold %d segments, want %d", tt.name, total, len(tt.texts))
+		}
+	}
+
+	sections := (&yttranscript.Transcript{Texts: twoTopics}).Segments(yttranscript.SegmentOptions{BlockWords: 10, MinSection: 30 * time.Second})
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, want 2", len(sections))
+	}
+	if sections[0].End != 120 || sections[1].End != 240 {
+		t.Errorf("sections end at %v and %v, want 120 and 240", sections[0].End, sections[1].End)
+	}
+	for i, vocab := range [][]string{bakingWords, astronomyWords} {
+		if len(sections[i].Terms) != 3 {
+			t.Errorf("section %d terms = %q, want 3", i, sections[i].Terms)
+		}
+		for _, term := range sections[i].Terms {
+			if !strings.Contains(" "+strings.Join(vocab, " ")+" ", " "+term+" ") {
+				t.Errorf("section %d term %q is not from its topic", i, term)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package yttranscript
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Word is a single word with a time interpolated from its caption segment.
+type Word struct {
+	Text  string
+	Start float64
+	End   float64
+}
+
+// Words splits the segment into words using tokenize, or strings.Fields if it
+// is nil. YouTube only times whole segments, so each word is given a share of
+// the segment's duration proportional to its length.
+func (t Text) Words(tokenize func(string) []string) []Word {
+	if tokenize == nil {
+		tokenize = strings.Fields
+	}
+	fields := tokenize(t.Content)
+	words := make([]Word, 0, len(fields))
+	if len(fields) == 0 {
+		return words
+	}
+
+	totalChars := 0
+	for _, field := range fields {
+		totalChars += utf8.RuneCountInString(field)
+	}
+
+	cursor := t.Start
+	for i, field := range fields {
+		end := t.End()
+		if i < len(fields)-1 && totalChars > 0 {
+			end = cursor + float64(utf8.RuneCountInString(field))/float64(totalChars)*t.Duration
+		}
+		words = append(words, Word{Text: field, Start: cursor, End: end})
+		cursor = end
+	}
+	return words
+}
+
+// defaultAbbreviations are words ending in a period that rarely end a
+// sentence.
+var defaultAbbreviations = []string{
+	"mr.", "mrs.", "ms.", "dr.", "prof.", "sr.", "jr.", "st.", "vs.",
+	"etc.", "e.g.", "i.e.", "approx.", "no.", "fig.", "inc.", "ltd.", "co.",
+}
+
+// SentenceOptions configures Sentences.
+type SentenceOptions struct {
+	// Tokenizer splits text into words. Defaults to strings.Fields.
+	Tokenizer func(string) []string
+	// Abbreviations are lowercase words, including their trailing period,
+	// that never end a sentence. Defaults to common English abbreviations.
+	Abbreviations []string
+}
+
+// Sentences rebuilds whole sentences across caption segment boundaries. A
+// sentence ends at a word ending in '.', '!', '?' or '…', optionally followed
+// by closing quotes or brackets, when the next word does not start with a
+// lowercase letter and the word is not a known abbreviation. Each sentence
+// starts at the interpolated time of its first word and ends at that of its
+// last word.
+func (t *Transcript) Sentences(opts SentenceOptions) *Transcript {
+	abbreviations := opts.Abbreviations
+	if abbreviations == nil {
+		abbreviations = defaultAbbreviations
+	}
+	isAbbreviation := make(map[string]bool, len(abbreviations))
+	for _, abbr := range abbreviations {
+		isAbbreviation[strings.ToLower(abbr)] = true
+	}
+
+	var words []Word
+	for _, text := range t.Texts {
+		words = append(words, text.Words(opts.Tokenizer)...)
+	}
+
+	var sentences []Text
+	start := 0
+	for i, word := range words {
+		last := i == len(words)-1
+		if !last && !endsSentence(word.Text, words[i+1].Text, isAbbreviation) {
+			continue
+		}
+		parts := make([]string, 0, i+1-start)
+		for _, w := range words[start : i+1] {
+			parts = append(parts, w.Text)
+		}
+		sentences = append(sentences, Text{
+			Start:    words[start].Start,
+			Duration: word.End - words[start].Start,
+			Content:  strings.Join(parts, " "),
+		})
+		start = i + 1
+	}
+
+	return t.withTexts(sentences)
+}
+
+func endsSentence(word, next string, isAbbreviation map[string]bool) bool {
+	trimmed := strings.TrimRight(word, `"'”’)]}»`)
+	if trimmed == "" {
+		return false
+	}
+	r, _ := utf8.DecodeLastRuneInString(trimmed)
+	if r != '.' && r != '!' && r != '?' && r != '…' {
+		return false
+	}
+	if r == '.' && isAbbreviation[strings.ToLower(trimmed)] {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(strings.TrimLeft(next, `"'“‘([{«¿¡`))
+	return !unicode.IsLower(first)
+}
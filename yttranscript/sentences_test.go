@@ -0,0 +1,109 @@
+package yttranscript_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		text     yttranscript.Text
+		tokenize func(string) []string
+		want     []yttranscript.Word
+	}{
+		{
+			text: yttranscript.Text{Start: 1, Duration: 6, Content: "ab cdef"},
+			want: []yttranscript.Word{{Text: "ab", Start: 1, End: 3}, {Text: "cdef", Start: 3, End: 7}},
+		},
+		{
+			text:     yttranscript.Text{Start: 0, Duration: 2, Content: "a-b"},
+			tokenize: func(s string) []string { return strings.Split(s, "-") },
+			want:     []yttranscript.Word{{Text: "a", Start: 0, End: 1}, {Text: "b", Start: 1, End: 2}},
+		},
+		{
+			text: yttranscript.Text{Start: 4, Duration: 1, Content: "solo"},
+			want: []yttranscript.Word{{Text: "solo", Start: 4, End: 5}},
+		},
+		{
+			text: yttranscript.Text{Start: 4, Duration: 1, Content: "   "},
+		},
+	}
+	for _, tt := range tests {
+		got := tt.text.Words(tt.tokenize)
+		if len(got) != len(tt.want) {
+			t.Errorf("Words(%q) = %+v, want %+v", tt.text.Content, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Text != tt.want[i].Text || math.Abs(got[i].Start-tt.want[i].Start) > 1e-9 || math.Abs(got[i].End-tt.want[i].End) > 1e-9 {
+				t.Errorf("Words(%q)[%d] = %+v, want %+v", tt.text.Content, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSentences(t *testing.T) {
+	texts := []yttranscript.Text{
+		{Start: 0, Duration: 2, Content: "Hello there. How"},
+		{Start: 2, Duration: 2, Content: "are you? Mr. Smith"},
+		{Start: 4, Duration: 2, Content: "said hi."},
+	}
+	tests := []struct {
+		name  string
+		texts []yttranscript.Text
+		opts  yttranscript.SentenceOptions
+		want  []string
+	}{
+		{
+			name:  "across segments",
+			texts: texts,
+			want:  []string{"Hello there.", "How are you?", "Mr. Smith said hi."},
+		},
+		{
+			name:  "no abbreviations",
+			texts: texts,
+			opts:  yttranscript.SentenceOptions{Abbreviations: []string{}},
+			want:  []string{"Hello there.", "How are you?", "Mr.", "Smith said hi."},
+		},
+		{
+			name:  "lowercase continuation",
+			texts: []yttranscript.Text{{Start: 0, Duration: 2, Content: "Wait... then go. Done"}},
+			want:  []string{"Wait... then go.", "Done"},
+		},
+		{
+			name:  "closing quote",
+			texts: []yttranscript.Text{{Start: 0, Duration: 2, Content: `"Stop!" She left.`}},
+			want:  []string{`"Stop!"`, "She left."},
+		},
+		{
+			name:  "custom abbreviation",
+			texts: []yttranscript.Text{{Start: 0, Duration: 2, Content: "Ask Hr. Berg. Now"}},
+			opts:  yttranscript.SentenceOptions{Abbreviations: []string{"Hr."}},
+			want:  []string{"Ask Hr. Berg.", "Now"},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		got := (&yttranscript.Transcript{Texts: tt.texts}).Sentences(tt.opts).Texts
+		var contents []string
+		for _, text := range got {
+			contents = append(contents, text.Content)
+		}
+		if strings.Join(contents, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("%s: sentences = %q, want %q", tt.name, contents, tt.want)
+		}
+	}
+
+	got := (&yttranscript.Transcript{Texts: texts}).Sentences(yttranscript.SentenceOptions{}).Texts
+	if got[0].Start != 0 || math.Abs(got[2].End()-6) > 1e-9 {
+		t.Errorf("sentences span %v to %v, want 0 to 6", got[0].Start, got[2].End())
+	}
+	if math.Abs(got[1].Start-got[0].End()) > 1e-9 {
+		t.Errorf("second sentence starts at %v, want %v", got[1].Start, got[0].End())
+	}
+}
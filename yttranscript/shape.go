@@ -0,0 +1,95 @@
+package yttranscript
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// singleCueMinSeconds is the duration above which a transcript consisting of
+// a single cue is treated as a pathological single-cue transcript.
+const singleCueMinSeconds = 60
+
+// IsEmpty reports whether the transcript contains no text at all.
+func (t *Transcript) IsEmpty() bool {
+	for _, text := range t.Texts {
+		if text.Content != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSingleCue reports whether the whole transcript is one long cue, which
+// makes its timing useless for navigation or subtitles.
+func (t *Transcript) IsSingleCue() bool {
+	return len(t.Texts) == 1 && t.Texts[0].Duration > singleCueMinSeconds
+}
+
+// checkShape fetches the track again in the srv3 format when the default
+// format came back empty or as a single cue and format fallback is enabled,
+// then classifies the result: empty transcripts yield ErrEmptyTranscript and
+// single-cue transcripts get a warning.
+func (c *Client) checkShape(ctx context.Context, track CaptionTrack, transcript *Transcript, clean CleanOptions) (*Transcript, error) {
+	if c.formatFallback && (transcript.IsEmpty() || transcript.IsSingleCue()) {
+		c.logger.Info("retrying caption fetch in srv3 format", "language", track.LanguageCode,
+			"segments", len(transcript.Texts))
+		alt, err := c.fetchSrv3Transcript(ctx, track, clean)
+		if err == nil && !alt.IsEmpty() && len(alt.Texts) > len(transcript.Texts) {
+			transcript.Texts = alt.Texts
+		} else if err != nil {
+			c.logger.Debug("srv3 caption fetch failed", "language", track.LanguageCode, "error", err)
+		}
+	}
+
+	if transcript.IsEmpty() {
+		return nil, ErrEmptyTranscript
+	}
+	if transcript.IsSingleCue() {
+		transcript.Warnings = append(transcript.Warnings, fmt.Sprintf(
+			"transcript is a single cue covering %.0f seconds", transcript.Texts[0].Duration))
+	}
+	return transcript, nil
+}
+
+// srv3Document is the timedtext format 3 document, which carries times in
+// milliseconds and splits lines into word segments.
+type srv3Document struct {
+	Paragraphs []struct {
+		Time     int64  `xml:"t,attr"`
+		Duration int64  `xml:"d,attr"`
+		Text     string `xml:",chardata"`
+		Segments []struct {
+			Text string `xml:",chardata"`
+		} `xml:"s"`
+	} `xml:"body>p"`
+}
+
+func (c *Client) fetchSrv3Transcript(ctx context.Context, track CaptionTrack, clean CleanOptions) (*Transcript, error) {
+	body, err := c.fetchURLContext(ctx, track.BaseURL+"&fmt=srv3")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch srv3 transcript: %w", err)
+	}
+
+	var doc srv3Document
+	if err := xml.Unmarshal([]byte(body), &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal srv3 transcript: %w", err)
+	}
+
+	transcript := &Transcript{LanguageCode: track.LanguageCode}
+	for _, p := range doc.Paragraphs {
+		var content strings.Builder
+		content.WriteString(p.Text)
+		for _, s := range p.Segments {
+			content.WriteString(s.Text)
+		}
+		transcript.Texts = append(transcript.Texts, Text{
+			Start:    float64(p.Time) / 1000,
+			Duration: float64(p.Duration) / 1000,
+			Content:  content.String(),
+		})
+	}
+	cleanTranscript(transcript, clean)
+	return transcript, nil
+}
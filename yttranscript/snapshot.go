@@ -0,0 +1,174 @@
+package yttranscript
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// shapeMaxDepth limits how deep the shape of a player response is recorded.
+// Deeper fields come and go between videos and would make the shape noisy.
+const shapeMaxDepth = 3
+
+// ShapeChange describes how the shape of a player response differs from the
+// stored baseline.
+type ShapeChange struct {
+	BaselineHash string
+	CurrentHash  string
+	Added        []string // Fields present now but not in the baseline.
+	Removed      []string // Fields in the baseline but missing now.
+}
+
+// ShapeMonitor compares the structure of player responses, meaning which
+// fields exist and their JSON types but not their values, against a baseline
+// stored on disk. Each distinct deviation is reported once through OnChange,
+// giving early warning of upstream changes that may break extraction.
+type ShapeMonitor struct {
+	baselinePath string
+	onChange     func(ShapeChange)
+
+	mu       sync.Mutex
+	baseline *responseShape
+	reported map[string]bool
+}
+
+// responseShape is the persisted form of a baseline.
+type responseShape struct {
+	Hash   string   `json:"hash"`
+	Fields []string `json:"fields"`
+}
+
+// NewShapeMonitor creates a monitor using the baseline stored at path. If the
+// file does not exist, the first playable response observed becomes the
+// baseline and is written there.
+func NewShapeMonitor(path string, onChange func(ShapeChange)) (*ShapeMonitor, error) {
+	m := &ShapeMonitor{
+		baselinePath: path,
+		onChange:     onChange,
+		reported:     make(map[string]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shape baseline: %w", err)
+	}
+	m.baseline = &responseShape{}
+	if err := json.Unmarshal(data, m.baseline); err != nil {
+		return nil, fmt.Errorf("failed to decode shape baseline: %w", err)
+	}
+	return m, nil
+}
+
+// WithShapeMonitor makes the client report player responses whose shape
+// differs from the monitor's baseline.
+func WithShapeMonitor(monitor *ShapeMonitor) Option {
+	return func(c *Client) {
+		c.shapeMonitor = monitor
+	}
+}
+
+func (m *ShapeMonitor) observe(data []byte) error {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	current := newResponseShape(doc)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.baseline == nil {
+		m.baseline = current
+		out, err := json.MarshalIndent(current, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(m.baselinePath, out, 0o644)
+	}
+
+	if current.Hash == m.baseline.Hash || m.reported[current.Hash] {
+		return nil
+	}
+	m.reported[current.Hash] = true
+	if m.onChange != nil {
+		m.onChange(ShapeChange{
+			BaselineHash: m.baseline.Hash,
+			CurrentHash:  current.Hash,
+			Added:        difference(current.Fields, m.baseline.Fields),
+			Removed:      difference(m.baseline.Fields, current.Fields),
+		})
+	}
+	return nil
+}
+
+func newResponseShape(doc interface{}) *responseShape {
+	set := make(map[string]bool)
+	collectShape(doc, "", 0, set)
+
+	fields := make([]string, 0, len(set))
+	for field := range set {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\n")))
+	return &responseShape{Hash: hex.EncodeToString(sum[:]), Fields: fields}
+}
+
+// collectShape records "path:type" entries for every field down to
+// shapeMaxDepth. Array elements share the path of their array with a "[]"
+// suffix so that the number of elements does not affect the shape.
+func collectShape(value interface{}, path string, depth int, set map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if path != "" {
+			set[path+":object"] = true
+		}
+		if depth == shapeMaxDepth {
+			return
+		}
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			collectShape(child, childPath, depth+1, set)
+		}
+	case []interface{}:
+		set[path+":array"] = true
+		if depth == shapeMaxDepth {
+			return
+		}
+		for _, item := range v {
+			collectShape(item, path+"[]", depth+1, set)
+		}
+	case string:
+		set[path+":string"] = true
+	case float64:
+		set[path+":number"] = true
+	case bool:
+		set[path+":bool"] = true
+	case nil:
+		set[path+":null"] = true
+	}
+}
+
+// difference returns the sorted entries of a that are not in sorted b.
+func difference(a, b []string) []string {
+	var out []string
+	for _, s := range a {
+		if i := sort.SearchStrings(b, s); i == len(b) || b[i] != s {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package yttranscript
+
+import "strings"
+
+// ExtractSpeakers returns a copy of the transcript with speaker labels moved
+// from the text into each segment's Speaker field. Labels written as a
+// speaker marker followed by "NAME:", "NAME:" alone with an uppercase name,
+// or "[Name]" start a new turn; following segments without a label keep the
+// current speaker. A bare marker starts a turn by an unnamed speaker.
+//
+// The markers, such as ">>", and the sound descriptions that are not names,
+// such as "[Music]", come from the locale pack of the transcript's language,
+// chosen among packs like CleanOptions.LocalePacks.
+func (t *Transcript) ExtractSpeakers(packs ...*LocalePack) *Transcript {
+	pack := localePack(t.LanguageCode, packs)
+	texts := make([]Text, len(t.Texts))
+	speaker := ""
+	for i, text := range t.Texts {
+		if name, rest, ok := pack.parseSpeakerLabel(text.Content); ok {
+			speaker = name
+			text.Content = rest
+		}
+		text.Speaker = speaker
+		texts[i] = text
+	}
+	return t.withTexts(texts)
+}
+
+// parseSpeakerLabel splits a leading speaker label off content. It reports
+// false if content starts without one.
+func (p *LocalePack) parseSpeakerLabel(content string) (speaker, rest string, ok bool) {
+	p.compile()
+	if m := p.speakerBracketRegex.FindStringSubmatch(content); m != nil {
+		name := strings.TrimSpace(m[2])
+		if !p.isSoundDescription(name) {
+			return name, content[len(m[0]):], true
+		}
+	}
+
+	m := p.speakerLabelRegex.FindStringSubmatch(content)
+	if m == nil || (m[1] == "" && m[2] == "") {
+		return "", content, false
+	}
+	return strings.TrimSpace(m[2]), content[len(m[0]):], true
+}
+
+// Turn is a run of consecutive segments spoken by one speaker.
+type Turn struct {
+	Speaker string // Empty if the speaker is not named.
+	Texts   []Text // Segments with speaker labels removed.
+}
+
+// Start returns the start time of the turn in seconds.
+func (t Turn) Start() float64 {
+	return t.Texts[0].Start
+}
+
+// SpeakerTurns extracts speaker labels like ExtractSpeakers and groups the
+// segments into turns. Every label starts a new turn, even when the same
+// speaker continues.
+func (t *Transcript) SpeakerTurns(packs ...*LocalePack) []Turn {
+	pack := localePack(t.LanguageCode, packs)
+	var turns []Turn
+	for _, text := range t.Texts {
+		name, rest, ok := pack.parseSpeakerLabel(text.Content)
+		if ok || len(turns) == 0 {
+			turns = append(turns, Turn{Speaker: name})
+		}
+		current := &turns[len(turns)-1]
+		if ok {
+			text.Content = rest
+		}
+		text.Speaker = current.Speaker
+		current.Texts = append(current.Texts, text)
+	}
+	return turns
+}
@@ -0,0 +1,108 @@
+package yttranscript_test
+
+import (
+	"testing"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestExtractSpeakers(t *testing.T) {
+	tests := []struct {
+		name        string
+		language    string
+		contents    []string
+		wantSpeaker []string
+		wantContent []string
+	}{
+		{
+			name:        "arrow and name",
+			contents:    []string{">> ALICE: hello", "how are you", ">> BOB SMITH: fine"},
+			wantSpeaker: []string{"ALICE", "ALICE", "BOB SMITH"},
+			wantContent: []string{"hello", "how are you", "fine"},
+		},
+		{
+			name:        "name without arrow",
+			contents:    []string{"DR. JONES: welcome", "Time: noon"},
+			wantSpeaker: []string{"DR. JONES", "DR. JONES"},
+			wantContent: []string{"welcome", "Time: noon"},
+		},
+		{
+			name:        "bare arrow starts unnamed turn",
+			contents:    []string{"ALICE: hi", ">> who is this"},
+			wantSpeaker: []string{"ALICE", ""},
+			wantContent: []string{"hi", "who is this"},
+		},
+		{
+			name:        "brackets",
+			contents:    []string{"[Narrator]: once upon a time", "[Music]", "[Background noise]", "[Alice] hi"},
+			wantSpeaker: []string{"Narrator", "Narrator", "Narrator", "Alice"},
+			wantContent: []string{"once upon a time", "[Music]", "[Background noise]", "hi"},
+		},
+		{
+			name:        "spanish pack",
+			language:    "es-MX",
+			contents:    []string{"- MARÍA: hola", "[Música]", "- ¿qué tal?", "[Ana] bien"},
+			wantSpeaker: []string{"MARÍA", "MARÍA", "", "Ana"},
+			wantContent: []string{"hola", "[Música]", "¿qué tal?", "bien"},
+		},
+		{
+			name:        "dash is not a marker in english",
+			contents:    []string{"ALICE: hi", "- a list item"},
+			wantSpeaker: []string{"ALICE", "ALICE"},
+			wantContent: []string{"hi", "- a list item"},
+		},
+		{
+			name:        "no labels",
+			contents:    []string{"just text", "more text"},
+			wantSpeaker: []string{"", ""},
+			wantContent: []string{"just text", "more text"},
+		},
+	}
+	for _, tt := range tests {
+		var texts []yttranscript.Text
+		for i, content := range tt.contents {
+			texts = append(texts, yttranscript.Text{Start: float64(i), Duration: 1, Content: content})
+		}
+		got := (&yttranscript.Transcript{LanguageCode: tt.language, Texts: texts}).ExtractSpeakers().Texts
+		for i, text := range got {
+			if text.Speaker != tt.wantSpeaker[i] || text.Content != tt.wantContent[i] {
+				t.Errorf("%s: segment %d = %q %q, want %q %q", tt.name, i, text.Speaker, text.Content, tt.wantSpeaker[i], tt.wantContent[i])
+			}
+		}
+		if texts[0].Speaker != "" {
+			t.Errorf("%s: ExtractSpeakers modified its receiver", tt.name)
+		}
+	}
+}
+
+func TestSpeakerTurns(t *testing.T) {
+	texts := []yttranscript.Text{
+		{Start: 0, Duration: 1, Content: "intro"},
+		{Start: 1, Duration: 1, Content: ">> ALICE: hi"},
+		{Start: 2, Duration: 1, Content: "still me"},
+		{Start: 3, Duration: 1, Content: ">> ALICE: new turn"},
+		{Start: 4, Duration: 1, Content: ">> BOB: hello"},
+	}
+	turns := (&yttranscript.Transcript{Texts: texts}).SpeakerTurns()
+	want := []struct {
+		speaker string
+		start   float64
+		count   int
+	}{
+		{"", 0, 1},
+		{"ALICE", 1, 2},
+		{"ALICE", 3, 1},
+		{"BOB", 4, 1},
+	}
+	if len(turns) != len(want) {
+		t.Fatalf("got %d turns, want %d", len(turns), len(want))
+	}
+	for i, turn := range turns {
+		if turn.Speaker != want[i].speaker || turn.Start() != want[i].start || len(turn.Texts) != want[i].count {
+			t.Errorf("turn %d = %q at %v with %d segments, want %q at %v with %d", i, turn.Speaker, turn.Start(), len(turn.Texts), want[i].speaker, want[i].start, want[i].count)
+		}
+	}
+	if turns[1].Texts[0].Content != "hi" {
+		t.Errorf("turn content = %q, want label removed", turns[1].Texts[0].Content)
+	}
+}
@@ -0,0 +1,31 @@
+package yttranscript
+
+// noCaptionTracksPath is the key under which ExtractionStats counts player
+// responses without any caption tracks.
+const noCaptionTracksPath = "none"
+
+func (c *Client) recordCaptionTracksPath(path string) {
+	if path == "" {
+		path = noCaptionTracksPath
+	}
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	if c.captionTracksPaths == nil {
+		c.captionTracksPaths = make(map[string]int)
+	}
+	c.captionTracksPaths[path]++
+}
+
+// ExtractionStats reports how many player responses had their caption tracks
+// found at each location, keyed by dotted path, with "none" counting
+// responses without tracks. A rising count for a fallback path is an early
+// sign that YouTube changed the response layout.
+func (c *Client) ExtractionStats() map[string]int {
+	c.statsMu.Lock()
+	defer c.statsMu.Unlock()
+	stats := make(map[string]int, len(c.captionTracksPaths))
+	for path, count := range c.captionTracksPaths {
+		stats[path] = count
+	}
+	return stats
+}
@@ -0,0 +1,126 @@
+package yttranscript
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LanguagePolicy decides what a call does when the video has no caption track
+// in the requested language. The policy that was applied is recorded in the
+// transcript's Provenance.Substitution.
+type LanguagePolicy string
+
+const (
+	// LanguageFail returns an error. This is the default.
+	LanguageFail LanguagePolicy = "fail"
+	// LanguageSubstituteDefault delivers the video's default caption track.
+	LanguageSubstituteDefault LanguagePolicy = "default"
+	// LanguageSubstituteTranslation delivers YouTube's machine translation of
+	// a translatable track into the requested language.
+	LanguageSubstituteTranslation LanguagePolicy = "translate"
+	// LanguageSubstituteManual delivers the first manually created track in
+	// any language.
+	LanguageSubstituteManual LanguagePolicy = "any-manual"
+)
+
+// LanguagePolicies lists every LanguagePolicy.
+var LanguagePolicies = []LanguagePolicy{
+	LanguageFail,
+	LanguageSubstituteDefault,
+	LanguageSubstituteTranslation,
+	LanguageSubstituteManual,
+}
+
+// ParseLanguagePolicy returns the policy with the given name.
+func ParseLanguagePolicy(name string) (LanguagePolicy, error) {
+	for _, policy := range LanguagePolicies {
+		if string(policy) == name {
+			return policy, nil
+		}
+	}
+	names := make([]string, len(LanguagePolicies))
+	for i, policy := range LanguagePolicies {
+		names[i] = string(policy)
+	}
+	return "", fmt.Errorf("unknown language policy %q, expected one of: %s", name, strings.Join(names, ", "))
+}
+
+// WithLanguagePolicy sets what the call does when the requested language is
+// missing, LanguageFail by default. A substituted transcript carries a warning naming the requested
+// language, and its Provenance records both.
+func WithLanguagePolicy(policy LanguagePolicy) CallOption {
+	return func(call *callConfig) {
+		call.languagePolicy = policy
+	}
+}
+
+// selectTrack finds the track for languageCode, applying policy when there
+// is none. The returned policy is empty when no substitution was needed.
+func selectTrack(playerResponse *PlayerResponse, languageCode string, policy LanguagePolicy) (CaptionTrack, LanguagePolicy, error) {
+	renderer := playerResponse.Captions.PlayerCaptionsTracklistRenderer
+	track, err := findTrack(renderer.CaptionTracks, languageCode)
+	if err == nil {
+		return track, "", nil
+	}
+
+	switch policy {
+	case LanguageSubstituteDefault:
+		return playerResponse.defaultTrack(), policy, nil
+	case LanguageSubstituteTranslation:
+		// Match target languages like tracks, so that "pt-BR" is translated
+		// into "pt" and "zh-TW" into "zh-Hant".
+		targets := make([]string, len(renderer.TranslationLanguages))
+		for i, language := range renderer.TranslationLanguages {
+			targets[i] = language.LanguageCode
+		}
+		if i := matchLanguageCode(targets, languageCode); i >= 0 {
+			for _, source := range renderer.CaptionTracks {
+				if source.IsTranslatable {
+					return translatedTrack(source, targets[i]), policy, nil
+				}
+			}
+		}
+	case LanguageSubstituteManual:
+		for _, track := range renderer.CaptionTracks {
+			if track.Kind != "asr" {
+				return track, policy, nil
+			}
+		}
+	}
+	return CaptionTrack{}, "", err
+}
+
+// defaultTrack returns the caption track YouTube selects by default for the
+// default audio track, or the first track when the response does not say.
+func (p *PlayerResponse) defaultTrack() CaptionTrack {
+	renderer := p.Captions.PlayerCaptionsTracklistRenderer
+	if i := renderer.DefaultAudioTrackIndex; i < len(renderer.AudioTracks) {
+		if index := renderer.AudioTracks[i].DefaultCaptionTrackIndex; index != nil && *index < len(renderer.CaptionTracks) {
+			return renderer.CaptionTracks[*index]
+		}
+	}
+	return renderer.CaptionTracks[0]
+}
+
+// recordSubstitution notes on transcript that it was delivered in place of
+// the requested language.
+func recordSubstitution(transcript *Transcript, requested string, policy LanguagePolicy) {
+	if policy == "" {
+		return
+	}
+	transcript.Provenance.RequestedLanguage = requested
+	transcript.Provenance.Substitution = policy
+	transcript.Warnings = append(transcript.Warnings, fmt.Sprintf(
+		"no transcript in language '%s'; substituted %s (policy %s)", requested, describeTrack(transcript.Provenance), policy))
+}
+
+func describeTrack(p Provenance) string {
+	switch {
+	case p.TranslatedFrom != "":
+		return fmt.Sprintf("machine translation from '%s'", p.TranslatedFrom)
+	case p.Kind == "asr":
+		return fmt.Sprintf("automatic captions in '%s'", p.LanguageCode)
+	default:
+		return fmt.Sprintf("captions in '%s'", p.LanguageCode)
+	}
+}
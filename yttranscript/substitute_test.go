@@ -0,0 +1,138 @@
+package yttranscript_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+func TestLanguagePolicy(t *testing.T) {
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name               string
+		language           string
+		policy             yttranscript.LanguagePolicy
+		wantLanguage       string
+		wantTranslatedFrom string
+		wantErr            bool
+	}{
+		{name: "available", language: "en", policy: yttranscript.LanguageFail, wantLanguage: "en"},
+		{name: "missing fails", language: "de", policy: yttranscript.LanguageFail, wantErr: true},
+		{name: "missing fails by default", language: "de", wantErr: true},
+		{name: "default track", language: "de", policy: yttranscript.LanguageSubstituteDefault, wantLanguage: "en"},
+		{name: "translation", language: "de", policy: yttranscript.LanguageSubstituteTranslation, wantLanguage: "de", wantTranslatedFrom: "en"},
+		{name: "no translation target", language: "fr", policy: yttranscript.LanguageSubstituteTranslation, wantErr: true},
+		{name: "any manual", language: "de", policy: yttranscript.LanguageSubstituteManual, wantLanguage: "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []yttranscript.CallOption
+			if tt.policy != "" {
+				opts = append(opts, yttranscript.WithLanguagePolicy(tt.policy))
+			}
+			transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, tt.language, opts...)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %s transcript, want error", transcript.LanguageCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			p := transcript.Provenance
+			if p.LanguageCode != tt.wantLanguage || p.TranslatedFrom != tt.wantTranslatedFrom || p.Kind == "asr" {
+				t.Errorf("provenance = %+v, want %s track translated from %q", p, tt.wantLanguage, tt.wantTranslatedFrom)
+			}
+			if tt.language == tt.wantLanguage && tt.wantTranslatedFrom == "" {
+				if p.Substitution != "" || len(transcript.Warnings) != 0 {
+					t.Errorf("unexpected substitution: %+v, warnings %q", p, transcript.Warnings)
+				}
+				return
+			}
+			if p.Substitution != tt.policy || p.RequestedLanguage != tt.language {
+				t.Errorf("provenance = %+v, want substitution %s for %s", p, tt.policy, tt.language)
+			}
+			if len(transcript.Warnings) != 1 || !strings.Contains(transcript.Warnings[0], "'"+tt.language+"'") {
+				t.Errorf("warnings = %q, want one naming %s", transcript.Warnings, tt.language)
+			}
+		})
+	}
+}
+
+func TestParseLanguagePolicy(t *testing.T) {
+	for _, policy := range yttranscript.LanguagePolicies {
+		got, err := yttranscript.ParseLanguagePolicy(string(policy))
+		if err != nil || got != policy {
+			t.Errorf("ParseLanguagePolicy(%q) = %q, %v", policy, got, err)
+		}
+	}
+	for _, name := range []string{"", "Fail", "translation"} {
+		if _, err := yttranscript.ParseLanguagePolicy(name); err == nil {
+			t.Errorf("ParseLanguagePolicy(%q) succeeded, want error", name)
+		}
+	}
+}
+
+func TestLanguagePolicyRegionalTags(t *testing.T) {
+	var player map[string]any
+	if err := json.Unmarshal(yttranscripttest.Fixture("player.json"), &player); err != nil {
+		t.Fatal(err)
+	}
+	renderer := player["captions"].(map[string]any)["playerCaptionsTracklistRenderer"].(map[string]any)
+	renderer["captionTracks"] = append(renderer["captionTracks"].([]any), map[string]any{
+		"baseUrl":      "https://www.youtube.com/api/timedtext?v=" + yttranscripttest.FixtureVideoID + "&lang=pt",
+		"name":         map[string]any{"simpleText": "Portuguese"},
+		"vssId":        ".pt",
+		"languageCode": "pt",
+	})
+	renderer["translationLanguages"] = append(renderer["translationLanguages"].([]any),
+		map[string]any{"languageCode": "pt", "languageName": map[string]any{"simpleText": "Portuguese"}},
+		map[string]any{"languageCode": "zh-Hant", "languageName": map[string]any{"simpleText": "Chinese (Traditional)"}},
+	)
+	body, err := json.Marshal(player)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	s.SetPlayerResponse(yttranscripttest.FixtureVideoID, body)
+	timedText := yttranscripttest.Fixture("timedtext_en.xml")
+	s.SetTimedText(yttranscripttest.FixtureVideoID, "pt", timedText)
+	s.SetTranslatedTimedText(yttranscripttest.FixtureVideoID, "en", "zh-Hant", timedText)
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		language           string
+		wantLanguage       string
+		wantTranslatedFrom string
+	}{
+		{language: "pt-BR", wantLanguage: "pt"},
+		{language: "zh-TW", wantLanguage: "zh-Hant", wantTranslatedFrom: "en"},
+		{language: "de-AT", wantLanguage: "de", wantTranslatedFrom: "en"},
+	}
+	for _, tt := range tests {
+		transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, tt.language,
+			yttranscript.WithLanguagePolicy(yttranscript.LanguageSubstituteTranslation))
+		if err != nil {
+			t.Errorf("%s: %v", tt.language, err)
+			continue
+		}
+		if p := transcript.Provenance; p.LanguageCode != tt.wantLanguage || p.TranslatedFrom != tt.wantTranslatedFrom {
+			t.Errorf("%s: provenance = %+v, want %s translated from %q", tt.language, p, tt.wantLanguage, tt.wantTranslatedFrom)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package yttranscript
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Extraction stages reported to Telemetry.
+const (
+	StageInnertubeConfig = "innertube_config" // API key scraped from the watch page.
+	StagePlayerResponse  = "player_response"  // Player request, by client profile.
+	StageCaptionTracks   = "caption_tracks"   // Caption track list, by response path.
+	StageCaptions        = "captions"         // Caption download, by format.
+)
+
+// ExtractionEvent is the outcome of one extraction step. It deliberately
+// carries no video IDs, URLs or error messages: only which strategy was
+// tried and whether it worked, plus a coarse error class.
+type ExtractionEvent struct {
+	Stage    string    `json:"stage"`
+	Strategy string    `json:"strategy"`
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"` // Playability status or "error".
+	Time     time.Time `json:"time"`
+}
+
+// errNoCaptionTracks is reported for player responses without caption
+// tracks, which is normal for some videos but a breakage signal when it
+// happens for all of them.
+var errNoCaptionTracks = errors.New("no caption tracks")
+
+// Telemetry receives extraction events so that organizations running many
+// instances can notice YouTube breakages early. Nothing is reported unless a
+// Telemetry is set with WithTelemetry. Report is called synchronously from
+// the fetching goroutines, so it must be safe for concurrent use and must
+// not block.
+type Telemetry interface {
+	Report(event ExtractionEvent)
+}
+
+// WithTelemetry reports extraction successes and failures to t.
+func WithTelemetry(t Telemetry) Option {
+	return func(c *Client) {
+		c.telemetry = t
+	}
+}
+
+func (c *Client) report(stage, strategy string, err error) {
+	if c.telemetry == nil {
+		return
+	}
+	event := ExtractionEvent{Stage: stage, Strategy: strategy, Success: err == nil, Time: time.Now().UTC()}
+	if err != nil {
+		event.Error = "error"
+		var playability *PlayabilityError
+		if errors.As(err, &playability) {
+			event.Error = playability.Status
+		}
+	}
+	c.telemetry.Report(event)
+}
+
+// telemetryBatchSize is how many events HTTPTelemetry sends per request.
+const telemetryBatchSize = 100
+
+// HTTPTelemetry posts extraction events as JSON arrays to an endpoint the
+// user controls. Events are buffered and sent in the background; when the
+// buffer is full new events are dropped rather than slowing down fetches.
+type HTTPTelemetry struct {
+	endpoint   string
+	httpClient *http.Client
+	events     chan ExtractionEvent
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewHTTPTelemetry starts sending events to endpoint, flushing at least
+// every interval. Call Close to send the remaining events and stop.
+func NewHTTPTelemetry(endpoint string, interval time.Duration) *HTTPTelemetry {
+	t := &HTTPTelemetry{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		events:     make(chan ExtractionEvent, 10*telemetryBatchSize),
+		done:       make(chan struct{}),
+	}
+	go t.run(interval)
+	return t
+}
+
+// Report implements Telemetry.
+func (t *HTTPTelemetry) Report(event ExtractionEvent) {
+	select {
+	case t.events <- event:
+	default:
+	}
+}
+
+// Close sends the buffered events and stops the background sender. Report
+// must not be called after Close.
+func (t *HTTPTelemetry) Close() {
+	t.closeOnce.Do(func() {
+		close(t.events)
+		<-t.done
+	})
+}
+
+func (t *HTTPTelemetry) run(interval time.Duration) {
+	defer close(t.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var batch []ExtractionEvent
+	for {
+		select {
+		case event, ok := <-t.events:
+			if !ok {
+				t.send(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= telemetryBatchSize {
+				t.send(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			t.send(batch)
+			batch = nil
+		}
+	}
+}
+
+// send posts a batch. Failures are ignored: telemetry must never affect the
+// tool itself.
+func (t *HTTPTelemetry) send(batch []ExtractionEvent) {
+	if len(batch) == 0 {
+		return
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return
+	}
+	resp, err := t.httpClient.Post(t.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
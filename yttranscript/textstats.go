@@ -0,0 +1,70 @@
+package yttranscript
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxStatsGaps is how many of the longest gaps Stats reports.
+const maxStatsGaps = 5
+
+// TranscriptStats summarizes the size of a transcript and how much of the
+// video its captions cover. Times are in seconds.
+type TranscriptStats struct {
+	Segments       int     `json:"segments"`
+	Words          int     `json:"words"`
+	Span           float64 `json:"span"`             // From the start of the first segment to the end of the last.
+	Covered        float64 `json:"covered"`          // Time during which some caption is shown.
+	Coverage       float64 `json:"coverage"`         // Covered as a share of Span, from 0 to 1.
+	WordsPerMinute float64 `json:"words_per_minute"` // Words per minute of covered time.
+	LongestGaps    []Gap   `json:"longest_gaps"`     // Longest stretches without captions, longest first.
+}
+
+// Gap is a stretch of a transcript without captions.
+type Gap struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// Duration returns the length of the gap in seconds.
+func (g Gap) Duration() float64 {
+	return g.End - g.Start
+}
+
+// Stats computes statistics of the transcript. Overlapping segments, common
+// in automatic captions, count their shared time once, so Covered and the
+// gaps reflect when captions are actually on screen.
+func (t *Transcript) Stats() TranscriptStats {
+	stats := TranscriptStats{Segments: len(t.Texts)}
+	if len(t.Texts) == 0 {
+		return stats
+	}
+
+	texts := make([]Text, len(t.Texts))
+	copy(texts, t.Texts)
+	sort.SliceStable(texts, func(i, j int) bool { return texts[i].Start < texts[j].Start })
+
+	var gaps []Gap
+	coveredUntil := texts[0].Start
+	for _, text := range texts {
+		stats.Words += len(strings.Fields(text.Content))
+		if text.Start > coveredUntil {
+			gaps = append(gaps, Gap{Start: coveredUntil, End: text.Start})
+		}
+		if end := text.End(); end > coveredUntil {
+			stats.Covered += end - max(text.Start, coveredUntil)
+			coveredUntil = end
+		}
+	}
+	stats.Span = coveredUntil - texts[0].Start
+	if stats.Span > 0 {
+		stats.Coverage = stats.Covered / stats.Span
+	}
+	if stats.Covered > 0 {
+		stats.WordsPerMinute = float64(stats.Words) / (stats.Covered / 60)
+	}
+
+	sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].Duration() > gaps[j].Duration() })
+	stats.LongestGaps = gaps[:min(len(gaps), maxStatsGaps)]
+	return stats
+}
@@ -0,0 +1,96 @@
+package yttranscript
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+)
+
+// timedTextListURL is the legacy caption endpoint, which lists and serves a
+// video's tracks without going through the InnerTube player.
+const timedTextListURL = "https://video.google.com/timedtext"
+
+// timedTextList is the track list returned for type=list.
+type timedTextList struct {
+	Tracks []struct {
+		Name         string `xml:"name,attr"`
+		LanguageCode string `xml:"lang_code,attr"`
+		LanguageName string `xml:"lang_original,attr"`
+		Kind         string `xml:"kind,attr"`
+		Default      bool   `xml:"lang_default,attr"`
+	} `xml:"track"`
+}
+
+// WithTimedTextFallback sets whether GetTranscript and ListTranscripts turn
+// to the legacy timedtext list endpoint when the player request fails. It is
+// enabled by default. Transcripts fetched this way have no title or chapters
+// and carry a warning. The list names no translation languages, so
+// LanguageSubstituteTranslation finds nothing to translate into.
+func WithTimedTextFallback(enabled bool) Option {
+	return func(c *Client) {
+		c.noTimedText = !enabled
+	}
+}
+
+// timedTextFallback lists the tracks of videoID from the timedtext endpoint
+// after the player request failed with err, and wraps them in a player
+// response so that track selection works as usual. It reports false if the
+// fallback is disabled or found no tracks.
+func (c *Client) timedTextFallback(ctx context.Context, videoID string, err error) (*PlayerResponse, bool) {
+	if c.noTimedText {
+		return nil, false
+	}
+	c.logger.Info("player request failed, trying timedtext list", "video_id", videoID, "error", err)
+	tracks, listErr := c.listTimedTextTracks(ctx, videoID)
+	if listErr == nil && len(tracks) == 0 {
+		listErr = fmt.Errorf("no tracks listed")
+	}
+	c.report(StageCaptionTracks, "timedtext_list", listErr)
+	if listErr != nil {
+		c.logger.Info("timedtext list failed", "video_id", videoID, "error", listErr)
+		return nil, false
+	}
+
+	var playerResponse PlayerResponse
+	playerResponse.VideoDetails.VideoID = videoID
+	playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks = tracks
+	return &playerResponse, true
+}
+
+// listTimedTextTracks fetches the track list of videoID from the timedtext
+// endpoint. The default track, if marked, comes first.
+func (c *Client) listTimedTextTracks(ctx context.Context, videoID string) ([]CaptionTrack, error) {
+	listXML, err := c.fetchURLContext(ctx, timedTextListURL+"?type=list&v="+url.QueryEscape(videoID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch timedtext list: %w", err)
+	}
+	var list timedTextList
+	if err := xml.Unmarshal([]byte(listXML), &list); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal timedtext list: %w", err)
+	}
+
+	var tracks []CaptionTrack
+	for _, listed := range list.Tracks {
+		query := url.Values{"v": {videoID}, "lang": {listed.LanguageCode}, "name": {listed.Name}}
+		vssID := "." + listed.LanguageCode
+		if listed.Kind != "" {
+			query.Set("kind", listed.Kind)
+			vssID = "a" + vssID
+		}
+		track := CaptionTrack{
+			BaseURL:        timedTextListURL + "?" + query.Encode(),
+			Name:           Name{SimpleText: listed.LanguageName},
+			VssID:          vssID,
+			LanguageCode:   listed.LanguageCode,
+			Kind:           listed.Kind,
+			IsTranslatable: true,
+		}
+		if listed.Default {
+			tracks = append([]CaptionTrack{track}, tracks...)
+		} else {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks, nil
+}
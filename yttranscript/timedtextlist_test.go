@@ -0,0 +1,75 @@
+package yttranscript_test
+
+import (
+	"strings"
+	"testing"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+func TestTimedTextFallback(t *testing.T) {
+	const videoID = "noPlayer123"
+	s := yttranscripttest.NewServer()
+	defer s.Close()
+	s.SetTimedText(videoID, "en", yttranscripttest.Fixture("timedtext_en.xml"))
+	s.SetTimedText(videoID, "de", []byte(`<transcript><text start="1" dur="2">Hallo</text></transcript>`))
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		language  string
+		wantFirst string // Empty when the call should fail.
+	}{
+		{name: "requested language", enabled: true, language: "en", wantFirst: "We're no strangers to love"},
+		{name: "default track", enabled: true, language: "", wantFirst: "Hallo"},
+		{name: "disabled", enabled: false, language: "en"},
+	}
+	for _, tt := range tests {
+		client, err := s.NewClient(yttranscript.WithTimedTextFallback(tt.enabled))
+		if err != nil {
+			t.Fatal(err)
+		}
+		transcript, err := client.GetTranscript(videoID, tt.language)
+		if tt.wantFirst == "" {
+			if err == nil {
+				t.Errorf("%s: GetTranscript succeeded", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if transcript.Texts[0].Content != tt.wantFirst {
+			t.Errorf("%s: first segment = %q, want %q", tt.name, transcript.Texts[0].Content, tt.wantFirst)
+		}
+		if len(transcript.Warnings) == 0 || !strings.Contains(transcript.Warnings[0], "timedtext list") {
+			t.Errorf("%s: warnings = %q, want the fallback noted", tt.name, transcript.Warnings)
+		}
+	}
+
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracks, err := client.ListTranscripts(videoID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var codes []string
+	for _, track := range tracks {
+		codes = append(codes, track.LanguageCode)
+	}
+	if strings.Join(codes, ",") != "de,en" {
+		t.Errorf("listed languages = %q, want default de first", codes)
+	}
+
+	transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transcript.Warnings) != 0 || transcript.Title == "" {
+		t.Errorf("player path used the fallback: title %q, warnings %q", transcript.Title, transcript.Warnings)
+	}
+}
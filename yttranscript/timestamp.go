@@ -0,0 +1,65 @@
+package yttranscript
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatTimestamp renders seconds as mm:ss, or h:mm:ss for times past an hour.
+func FormatTimestamp(seconds float64) string {
+	total := int(seconds)
+	h, m, s := total/3600, total/60%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+// timestampFields names the fields of an "h:mm:ss" timestamp in errors.
+var timestampFields = []string{"hours", "minutes", "seconds"}
+
+// ParseTimestamp parses a time in a video as written by FormatTimestamp,
+// "h:mm:ss" or "m:ss", as plain seconds, or as a Go duration such as
+// "1h23m". Seconds may have a fractional part. Minutes and seconds after the
+// first field must be below 60, and negative, infinite and NaN times are
+// rejected.
+func ParseTimestamp(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil && strings.ContainsAny(s, "hms") {
+		if d < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q: negative", s)
+		}
+		return d, nil
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q: too many fields", s)
+	}
+	names := timestampFields[len(timestampFields)-len(parts):]
+	var seconds float64
+	for i, part := range parts {
+		var n float64
+		var err error
+		if i < len(parts)-1 {
+			var whole int
+			whole, err = strconv.Atoi(part)
+			n = float64(whole)
+		} else {
+			n, err = strconv.ParseFloat(part, 64)
+		}
+		if err != nil || strings.HasPrefix(part, "-") || strings.HasPrefix(part, "+") || math.IsNaN(n) || math.IsInf(n, 0) {
+			return 0, fmt.Errorf("invalid timestamp %q: bad %s %q", s, names[i], part)
+		}
+		if i > 0 && n >= 60 {
+			return 0, fmt.Errorf("invalid timestamp %q: %s must be below 60", s, names[i])
+		}
+		seconds = seconds*60 + n
+	}
+	if seconds >= math.MaxInt64/float64(time.Second) {
+		return 0, fmt.Errorf("invalid timestamp %q: out of range", s)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,84 @@
+package yttranscript_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr string // Substring of the error, if one is expected.
+	}{
+		{in: "0", want: 0},
+		{in: "42", want: 42 * time.Second},
+		{in: "42.5", want: 42500 * time.Millisecond},
+		{in: " 90 ", want: 90 * time.Second},
+		{in: "1:05", want: 65 * time.Second},
+		{in: "01:05.25", want: 65250 * time.Millisecond},
+		{in: "1:02:03", want: time.Hour + 2*time.Minute + 3*time.Second},
+		{in: "1h23m", want: time.Hour + 23*time.Minute},
+		{in: "90s", want: 90 * time.Second},
+		{in: "90:00", want: 90 * time.Minute},
+		{in: "100:00:00", want: 100 * time.Hour},
+		{in: "", wantErr: "bad seconds"},
+		{in: "abc", wantErr: "bad seconds"},
+		{in: "1:2:3:4", wantErr: "too many fields"},
+		{in: "-5", wantErr: "bad seconds"},
+		{in: "+5", wantErr: "bad seconds"},
+		{in: "-1m", wantErr: "negative"},
+		{in: "NaN", wantErr: "bad seconds"},
+		{in: "Inf", wantErr: "bad seconds"},
+		{in: "-Inf", wantErr: "bad seconds"},
+		{in: "1:NaN", wantErr: "bad seconds"},
+		{in: "1e400", wantErr: "bad seconds"},
+		{in: "1:60", wantErr: "seconds must be below 60"},
+		{in: "1:75:99", wantErr: "minutes must be below 60"},
+		{in: "1:05:60", wantErr: "seconds must be below 60"},
+		{in: "x:10", wantErr: "bad minutes"},
+		{in: "-1:10", wantErr: "bad minutes"},
+		{in: "1:-5:00", wantErr: "bad minutes"},
+		{in: "h:00:00", wantErr: "bad hours"},
+		{in: "9999999999:00:00", wantErr: "out of range"},
+	}
+	for _, tt := range tests {
+		got, err := yttranscript.ParseTimestamp(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseTimestamp(%q) = %v, %v, want error containing %q", tt.in, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTimestamp(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "00:00"},
+		{5.9, "00:05"},
+		{65, "01:05"},
+		{3599, "59:59"},
+		{3723, "1:02:03"},
+	}
+	for _, tt := range tests {
+		if got := yttranscript.FormatTimestamp(tt.in); got != tt.want {
+			t.Errorf("FormatTimestamp(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+		if tt.in == float64(int(tt.in)) {
+			back, err := yttranscript.ParseTimestamp(tt.want)
+			if err != nil || back.Seconds() != tt.in {
+				t.Errorf("ParseTimestamp(FormatTimestamp(%v)) = %v, %v", tt.in, back, err)
+			}
+		}
+	}
+}
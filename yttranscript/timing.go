@@ -0,0 +1,102 @@
+package yttranscript
+
+import (
+	"math"
+	"time"
+)
+
+// Shift returns a copy of the transcript with every segment and chapter
+// moved by offset, for syncing captions against a copy of the video that
+// was trimmed or has an intro added. With a negative offset, segments that
+// would end before zero are dropped and those that would start before zero
+// are cut to start at zero; the chapter in progress at zero starts at zero.
+func (t *Transcript) Shift(offset time.Duration) *Transcript {
+	seconds := offset.Seconds()
+	texts := make([]Text, 0, len(t.Texts))
+	for _, text := range t.Texts {
+		start, end := text.Start+seconds, text.End()+seconds
+		if end < 0 || end == 0 && text.Duration > 0 {
+			continue
+		}
+		text.Start, text.Duration = max(start, 0), end-max(start, 0)
+		texts = append(texts, text)
+	}
+
+	out := t.withTexts(texts)
+	out.Chapters = nil
+	for i, chapter := range t.Chapters {
+		chapter.Start += seconds
+		if chapter.Start < 0 {
+			if i+1 < len(t.Chapters) && t.Chapters[i+1].Start+seconds <= 0 {
+				continue
+			}
+			chapter.Start = 0
+		}
+		out.Chapters = append(out.Chapters, chapter)
+	}
+	return out
+}
+
+// Scale returns a copy of the transcript with all times multiplied by
+// factor, for syncing captions against a copy of the video played at a
+// different speed, such as one converted between 25 and 23.976 frames per
+// second (a factor of 25/23.976 stretches the timing to match). A factor of
+// zero or less returns an unchanged copy.
+func (t *Transcript) Scale(factor float64) *Transcript {
+	if factor <= 0 {
+		factor = 1
+	}
+	texts := make([]Text, len(t.Texts))
+	for i, text := range t.Texts {
+		text.Start *= factor
+		text.Duration *= factor
+		texts[i] = text
+	}
+
+	out := t.withTexts(texts)
+	out.Chapters = nil
+	for _, chapter := range t.Chapters {
+		chapter.Start *= factor
+		out.Chapters = append(out.Chapters, chapter)
+	}
+	return out
+}
+
+// Slice returns a copy of the transcript with only the segments shown
+// between from and to, cut to start no earlier than from and end no later
+// than to, for quoting part of a long video. Times stay relative to the
+// start of the video; call Shift with -from to make them start at zero. A
+// to of zero or less means the end of the transcript. Chapters starting in
+// the range are kept, and the chapter in progress at from starts at from.
+func (t *Transcript) Slice(from, to time.Duration) *Transcript {
+	start, end := from.Seconds(), math.Inf(1)
+	if to > 0 {
+		end = to.Seconds()
+	}
+	var texts []Text
+	for _, text := range t.Texts {
+		textEnd := text.End()
+		if textEnd < start || text.Start >= end || textEnd == start && text.Duration > 0 {
+			continue
+		}
+		text.Start = max(text.Start, start)
+		text.Duration = min(textEnd, end) - text.Start
+		texts = append(texts, text)
+	}
+
+	out := t.withTexts(texts)
+	out.Chapters = nil
+	for i, chapter := range t.Chapters {
+		if chapter.Start >= end {
+			break
+		}
+		if chapter.Start < start {
+			if i+1 < len(t.Chapters) && t.Chapters[i+1].Start <= start {
+				continue
+			}
+			chapter.Start = start
+		}
+		out.Chapters = append(out.Chapters, chapter)
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package yttranscript_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+func TestShiftScale(t *testing.T) {
+	base := &yttranscript.Transcript{
+		Texts:    []yttranscript.Text{{Start: 0, Duration: 2, Content: "a"}, {Start: 1.5, Duration: 2, Content: "b"}, {Start: 4, Duration: 1, Content: "c"}},
+		Chapters: []yttranscript.Chapter{{Start: 0, Title: "x"}, {Start: 1, Title: "y"}, {Start: 5, Title: "z"}},
+	}
+	tests := []struct {
+		name         string
+		apply        func(*yttranscript.Transcript) *yttranscript.Transcript
+		wantTexts    []yttranscript.Text
+		wantChapters []yttranscript.Chapter
+	}{
+		{
+			name:         "shift forward",
+			apply:        func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Shift(time.Second) },
+			wantTexts:    []yttranscript.Text{{Start: 1, Duration: 2, Content: "a"}, {Start: 2.5, Duration: 2, Content: "b"}, {Start: 5, Duration: 1, Content: "c"}},
+			wantChapters: []yttranscript.Chapter{{Start: 1, Title: "x"}, {Start: 2, Title: "y"}, {Start: 6, Title: "z"}},
+		},
+		{
+			name:         "shift back drops and cuts",
+			apply:        func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Shift(-2 * time.Second) },
+			wantTexts:    []yttranscript.Text{{Start: 0, Duration: 1.5, Content: "b"}, {Start: 2, Duration: 1, Content: "c"}},
+			wantChapters: []yttranscript.Chapter{{Start: 0, Title: "y"}, {Start: 3, Title: "z"}},
+		},
+		{
+			name:         "scale",
+			apply:        func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Scale(2) },
+			wantTexts:    []yttranscript.Text{{Start: 0, Duration: 4, Content: "a"}, {Start: 3, Duration: 4, Content: "b"}, {Start: 8, Duration: 2, Content: "c"}},
+			wantChapters: []yttranscript.Chapter{{Start: 0, Title: "x"}, {Start: 2, Title: "y"}, {Start: 10, Title: "z"}},
+		},
+		{
+			name:         "scale then shift",
+			apply:        func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Scale(2).Shift(time.Second) },
+			wantTexts:    []yttranscript.Text{{Start: 1, Duration: 4, Content: "a"}, {Start: 4, Duration: 4, Content: "b"}, {Start: 9, Duration: 2, Content: "c"}},
+			wantChapters: []yttranscript.Chapter{{Start: 1, Title: "x"}, {Start: 3, Title: "y"}, {Start: 11, Title: "z"}},
+		},
+		{
+			name:         "non-positive scale is a copy",
+			apply:        func(t *yttranscript.Transcript) *yttranscript.Transcript { return t.Scale(0) },
+			wantTexts:    base.Texts,
+			wantChapters: base.Chapters,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.apply(base)
+			if !reflect.DeepEqual(got.Texts, tt.wantTexts) {
+				t.Errorf("texts = %+v, want %+v", got.Texts, tt.wantTexts)
+			}
+			if !reflect.DeepEqual(got.Chapters, tt.wantChapters) {
+				t.Errorf("chapters = %+v, want %+v", got.Chapters, tt.wantChapters)
+			}
+		})
+	}
+	if base.Texts[0].Start != 0 || base.Chapters[2].Start != 5 {
+		t.Errorf("input transcript was modified: %+v", base)
+	}
+}
+
+func TestSlice(t *testing.T) {
+	transcript := fetchFixture(t)
+	tests := []struct {
+		name         string
+		from, to     time.Duration
+		wantStarts   []float64
+		wantEnd      float64
+		wantChapters []yttranscript.Chapter
+	}{
+		{
+			name:         "middle",
+			from:         30 * time.Second,
+			to:           45 * time.Second,
+			wantStarts:   []float64{30, 31.2, 35.6, 40.72, 43.32},
+			wantEnd:      45,
+			wantChapters: []yttranscript.Chapter{{Start: 30, Title: "Verse"}, {Start: 43, Title: "Chorus"}},
+		},
+		{
+			name:         "to end",
+			from:         55 * time.Second,
+			wantStarts:   []float64{55, 56.6},
+			wantEnd:      transcript.Texts[len(transcript.Texts)-1].End(),
+			wantChapters: []yttranscript.Chapter{{Start: 55, Title: "Chorus"}},
+		},
+		{
+			name:         "past the end",
+			from:         2 * time.Minute,
+			to:           3 * time.Minute,
+			wantChapters: []yttranscript.Chapter{{Start: 120, Title: "Chorus"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := transcript.Slice(tt.from, tt.to)
+			var starts []float64
+			for _, text := range got.Texts {
+				starts = append(starts, text.Start)
+			}
+			if !reflect.DeepEqual(starts, tt.wantStarts) {
+				t.Fatalf("starts = %v, want %v", starts, tt.wantStarts)
+			}
+			if len(got.Texts) > 0 {
+				if end := got.Texts[len(got.Texts)-1].End(); end != tt.wantEnd {
+					t.Errorf("end = %v, want %v", end, tt.wantEnd)
+				}
+			}
+			if !reflect.DeepEqual(got.Chapters, tt.wantChapters) {
+				t.Errorf("chapters = %+v, want %+v", got.Chapters, tt.wantChapters)
+			}
+		})
+	}
+}
+
+// fetchFixture returns the English transcript of the fake server's video.
+func fetchFixture(t *testing.T) *yttranscript.Transcript {
+	t.Helper()
+	s := yttranscripttest.NewServer()
+	t.Cleanup(s.Close)
+	client, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transcript, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transcript
+}
@@ -0,0 +1,89 @@
+package yttranscript
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+)
+
+// TranscriptPair is an original transcript together with its machine
+// translation. Translated.Texts is index-aligned with Original.Texts.
+type TranscriptPair struct {
+	Original   *Transcript
+	Translated *Transcript
+}
+
+// GetTranscriptPair fetches the transcript in srcLang and YouTube's automatic
+// translation of it into dstLang with a single player request. The translated
+// segments are aligned to the original by start time, so Translated.Texts[i]
+// always covers the same moment as Original.Texts[i].
+func (c *Client) GetTranscriptPair(videoID, srcLang, dstLang string, opts ...CallOption) (*TranscriptPair, error) {
+	call := newCallConfig(opts)
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player response: %w", err)
+	}
+	tracks := playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+	if len(tracks) == 0 {
+		return nil, fmt.Errorf("no transcripts available for this video")
+	}
+
+	srcTrack, err := findTrack(tracks, srcLang)
+	if err != nil {
+		return nil, err
+	}
+	if !srcTrack.IsTranslatable {
+		return nil, fmt.Errorf("transcript for language '%s' cannot be translated", srcTrack.LanguageCode)
+	}
+
+	ctx := context.Background()
+	original, err := c.fetchVideoTranscript(ctx, videoID, playerResponse, srcTrack, call)
+	if err != nil {
+		return nil, err
+	}
+	translated, err := c.fetchVideoTranscript(ctx, videoID, playerResponse, translatedTrack(srcTrack, dstLang), call)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch translation: %w", err)
+	}
+
+	translated.Texts = alignTexts(original.Texts, translated.Texts)
+	return &TranscriptPair{Original: original, Translated: translated}, nil
+}
+
+// translatedTrack returns a track that delivers track machine-translated into
+// languageCode.
+func translatedTrack(track CaptionTrack, languageCode string) CaptionTrack {
+	translated := track
+	translated.BaseURL = track.BaseURL + "&tlang=" + url.QueryEscape(languageCode)
+	translated.LanguageCode = languageCode
+	translated.translatedFrom = track.LanguageCode
+	return translated
+}
+
+// alignTexts returns one entry of other per entry of base, matching each base
+// segment with the other segment whose start time is closest. Timing is taken
+// from base so the result is index-aligned with it.
+func alignTexts(base, other []Text) []Text {
+	if len(base) == len(other) {
+		aligned := make([]Text, len(base))
+		for i := range base {
+			aligned[i] = Text{Start: base[i].Start, Duration: base[i].Duration, Content: other[i].Content}
+		}
+		return aligned
+	}
+
+	aligned := make([]Text, len(base))
+	j := 0
+	for i, text := range base {
+		aligned[i] = Text{Start: text.Start, Duration: text.Duration}
+		if len(other) == 0 {
+			continue
+		}
+		for j+1 < len(other) && math.Abs(other[j+1].Start-text.Start) <= math.Abs(other[j].Start-text.Start) {
+			j++
+		}
+		aligned[i].Content = other[j].Content
+	}
+	return aligned
+}
@@ -0,0 +1,60 @@
+package yttranscript
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// defaultMaxIdleConnsPerHost replaces net/http's default of two idle
+// connections per host. Nearly every request goes to www.youtube.com, so a
+// client shared by many goroutines would otherwise keep reopening
+// connections.
+const defaultMaxIdleConnsPerHost = 16
+
+// newTransport returns the pooled transport a Client uses unless WithTransport
+// replaces it.
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	return transport
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections per host are kept
+// for reuse. Raise it when sharing one Client across more goroutines than the
+// default of 16. Like the other connection options it configures the
+// client's own transport and has no effect together with WithTransport.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Client) {
+		c.transport.MaxIdleConnsPerHost = n
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2. It is enabled by default; disabling
+// it makes concurrent requests use separate HTTP/1.1 connections instead of
+// being multiplexed over one.
+func WithHTTP2(enabled bool) Option {
+	return func(c *Client) {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetHTTP2(enabled)
+		c.transport.Protocols = protocols
+	}
+}
+
+// WithKeepAlive tunes connection reuse: idleTimeout is how long an idle
+// connection stays in the pool, and probeInterval how often TCP keep-alive
+// probes are sent on open connections. A negative idleTimeout disables
+// connection reuse altogether; a negative probeInterval disables probes.
+func WithKeepAlive(idleTimeout, probeInterval time.Duration) Option {
+	return func(c *Client) {
+		if idleTimeout < 0 {
+			c.transport.DisableKeepAlives = true
+		} else {
+			c.transport.DisableKeepAlives = false
+			c.transport.IdleConnTimeout = idleTimeout
+		}
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: probeInterval}
+		c.transport.DialContext = dialer.DialContext
+	}
+}
@@ -0,0 +1,43 @@
+package yttranscript_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"yt-transcript/yttranscript"
+	"yt-transcript/yttranscripttest"
+)
+
+func BenchmarkGetTranscriptParallel(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		opts []yttranscript.Option
+	}{
+		{"NetHTTPDefaults", []yttranscript.Option{yttranscript.WithMaxIdleConnsPerHost(http.DefaultMaxIdleConnsPerHost)}},
+		{"Pooled", nil},
+		{"Pooled64", []yttranscript.Option{yttranscript.WithMaxIdleConnsPerHost(64), yttranscript.WithKeepAlive(90*time.Second, 30*time.Second)}},
+		{"NoKeepAlive", []yttranscript.Option{yttranscript.WithKeepAlive(-1, -1)}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			s := yttranscripttest.NewServer()
+			defer s.Close()
+			client, err := yttranscript.New(bm.opts...)
+			if err != nil {
+				b.Fatal(err)
+			}
+			yttranscript.WrapTransport(client, s.TransportWith)
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := client.GetTranscript(yttranscripttest.FixtureVideoID, "en"); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
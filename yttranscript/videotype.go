@@ -0,0 +1,89 @@
+package yttranscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// shortsURL serves Shorts directly and redirects other videos to their
+// watch page.
+const shortsURL = "https://www.youtube.com/shorts/"
+
+// shortMaxSeconds is the longest a Short can be.
+const shortMaxSeconds = 180
+
+// VideoType is the kind of upload a video is.
+type VideoType string
+
+const (
+	VideoRegular    VideoType = "video"    // An ordinary upload.
+	VideoShort      VideoType = "short"    // A vertical video of up to three minutes.
+	VideoLive       VideoType = "live"     // A live broadcast in progress.
+	VideoUpcoming   VideoType = "upcoming" // A scheduled premiere or live stream that has not started.
+	VideoLiveReplay VideoType = "replay"   // The recording of a live broadcast that has ended.
+)
+
+// GetVideoType reports what kind of upload a video is. Telling Shorts from
+// regular videos takes an extra request, which is only made for videos short
+// enough to be one.
+func (c *Client) GetVideoType(videoID string, opts ...CallOption) (VideoType, error) {
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, newCallConfig(opts))
+	if errors.Is(err, ErrLiveStreamOffline) {
+		return VideoUpcoming, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get player response: %w", err)
+	}
+
+	if videoType := liveVideoType(playerResponse); videoType != VideoRegular {
+		return videoType, nil
+	}
+	if seconds, _ := strconv.Atoi(playerResponse.VideoDetails.LengthSeconds); seconds > shortMaxSeconds {
+		return VideoRegular, nil
+	}
+	short, err := c.isShort(videoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check for short: %w", err)
+	}
+	if short {
+		return VideoShort, nil
+	}
+	return VideoRegular, nil
+}
+
+// liveVideoType classifies a video from the live fields of its player
+// response. Shorts are reported as VideoRegular.
+func liveVideoType(playerResponse *PlayerResponse) VideoType {
+	details := playerResponse.VideoDetails
+	switch {
+	case details.IsUpcoming:
+		return VideoUpcoming
+	case details.IsLive:
+		return VideoLive
+	case details.IsLiveContent:
+		return VideoLiveReplay
+	}
+	return VideoRegular
+}
+
+// isShort reports whether YouTube serves the video at its Shorts URL rather
+// than redirecting to the watch page.
+func (c *Client) isShort(videoID string) (bool, error) {
+	client := *c.httpClient
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	req, err := http.NewRequest(http.MethodHead, shortsURL+videoID, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
@@ -0,0 +1,57 @@
+package yttranscript
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// videoIDRegex matches the 11-character IDs YouTube gives videos.
+var videoIDRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// videoPathPrefixes are the URL paths that are followed by a video ID.
+var videoPathPrefixes = []string{"/shorts/", "/live/", "/embed/", "/v/", "/e/"}
+
+// DeepLink returns a short link that opens the video at the given time,
+// such as https://youtu.be/dQw4w9WgXcQ?t=43, for citing a passage. Seconds
+// are rounded down, as YouTube ignores fractions.
+func DeepLink(videoID string, seconds float64) string {
+	return fmt.Sprintf("https://youtu.be/%s?t=%d", videoID, int(max(seconds, 0)))
+}
+
+// ParseVideoID returns the video ID in s, which is either a bare ID or a
+// YouTube URL: watch pages, youtu.be short links, Shorts, live and embed
+// URLs are all understood, on any youtube.com subdomain.
+func ParseVideoID(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if videoIDRegex.MatchString(s) {
+		return s, nil
+	}
+	raw := s
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid video URL %q: %w", s, err)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	var id string
+	switch {
+	case host == "youtu.be":
+		id, _, _ = strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	case host == "youtube.com" || strings.HasSuffix(host, ".youtube.com") || host == "youtube-nocookie.com":
+		id = u.Query().Get("v")
+		for _, prefix := range videoPathPrefixes {
+			if rest, ok := strings.CutPrefix(u.Path, prefix); ok {
+				id, _, _ = strings.Cut(rest, "/")
+			}
+		}
+	}
+	if !videoIDRegex.MatchString(id) {
+		return "", fmt.Errorf("no video ID found in %q", s)
+	}
+	return id, nil
+}
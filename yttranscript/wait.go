@@ -0,0 +1,56 @@
+package yttranscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultWaitInterval is the poll interval WaitForTranscript uses when given
+// none.
+const defaultWaitInterval = time.Minute
+
+// WaitForTranscript polls a video's caption list every pollInterval until a
+// track in languageCode exists, then returns its transcript. This is meant
+// for fresh uploads, whose automatic captions often appear minutes to hours
+// after the video. An empty languageCode waits for any track, and a
+// WithLanguagePolicy option lets a substitute end the wait.
+//
+// Playability errors that waiting cannot fix, such as a private or removed
+// video, are returned at once. Other failures, including network errors and
+// empty tracks, are logged and retried. When ctx is done before a transcript
+// is ready, the last failure is returned wrapped together with ctx.Err().
+func (c *Client) WaitForTranscript(ctx context.Context, videoID, languageCode string, pollInterval time.Duration, opts ...CallOption) (*Transcript, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultWaitInterval
+	}
+	call := newCallConfig(opts)
+	for {
+		transcript, err := c.getTranscript(ctx, videoID, languageCode, call)
+		if err == nil {
+			return transcript, nil
+		}
+		if isPermanent(err) {
+			return nil, err
+		}
+		c.logger.Debug("transcript not ready, waiting", "video_id", videoID, "language", languageCode,
+			"interval", pollInterval, "error", err)
+
+		select {
+		case <-time.After(pollInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("gave up waiting for transcript: %w", errors.Join(ctx.Err(), err))
+		}
+	}
+}
+
+// isPermanent reports whether err is a playability error that polling will
+// not resolve. Offline live streams and unplayable videos, which include
+// videos still processing, may become available and are not permanent.
+func isPermanent(err error) bool {
+	if !errors.Is(err, ErrNotPlayable) {
+		return false
+	}
+	return !errors.Is(err, ErrLiveStreamOffline) && !errors.Is(err, ErrUnplayable)
+}
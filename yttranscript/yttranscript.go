@@ -2,28 +2,58 @@ package yttranscript
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
-	"html"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 	"regexp"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"yt-transcript/httpcache"
+	"yt-transcript/replay"
 )
 
 const (
+	youtubeURL      = "https://www.youtube.com"
 	watchURL        = "https://www.youtube.com/watch?v="
 	innertubeAPIURL = "https://www.youtube.com/youtubei/v1/player?key="
+	consentHost     = "consent.youtube.com"
 )
 
+// defaultConfigTTL is how long a scraped InnerTube configuration is reused
+// unless WithConfigTTL says otherwise.
+const defaultConfigTTL = time.Hour
+
+// ErrConsentRequired is returned when YouTube keeps redirecting to its cookie
+// consent page even after consent cookies have been set.
+var ErrConsentRequired = errors.New("youtube cookie consent required")
+
 // CaptionTrack defines the structure for a caption track from the YouTube API.
 type CaptionTrack struct {
-	BaseURL      string `json:"baseUrl"`
-	Name         Name   `json:"name"`
-	LanguageCode string `json:"languageCode"`
-	Kind         string `json:"kind"` // "asr" for automatic speech recognition, "manual" for manually created captions.
+	BaseURL        string `json:"baseUrl"`
+	Name           Name   `json:"name"`
+	VssID          string `json:"vssId"`
+	LanguageCode   string `json:"languageCode"`
+	Kind           string `json:"kind"` // "asr" for automatic speech recognition, "manual" for manually created captions.
+	IsTranslatable bool   `json:"isTranslatable"`
+
+	translatedFrom string // Source language code if this is a machine translation of another track.
+}
+
+// TranslationLanguage is a language that translatable caption tracks can be
+// machine-translated into.
+type TranslationLanguage struct {
+	LanguageCode string        `json:"languageCode"`
+	LanguageName FormattedText `json:"languageName"`
 }
 
 // Name represents the name of a caption track.
@@ -31,23 +61,97 @@ type Name struct {
 	SimpleText string `json:"simpleText"`
 }
 
-// PlayerResponse represents the structure of the JSON response from the InnerTube API.
+// FormattedText is a text value that YouTube sends either as simpleText or as
+// a list of runs.
+type FormattedText struct {
+	SimpleText string `json:"simpleText"`
+	Runs       []struct {
+		Text string `json:"text"`
+	} `json:"runs"`
+}
+
+// String returns the plain text content.
+func (t FormattedText) String() string {
+	if t.SimpleText != "" {
+		return t.SimpleText
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// PlayerResponse represents the structure of the JSON response from the
+// InnerTube API. Fields whose type YouTube changes are left empty rather than
+// failing the decoding.
 type PlayerResponse struct {
 	Captions struct {
 		PlayerCaptionsTracklistRenderer struct {
-			CaptionTracks []CaptionTrack `json:"captionTracks"`
+			CaptionTracks        []CaptionTrack        `json:"captionTracks"`
+			TranslationLanguages []TranslationLanguage `json:"translationLanguages"`
+			AudioTracks          []struct {
+				DefaultCaptionTrackIndex *int `json:"defaultCaptionTrackIndex"`
+			} `json:"audioTracks"`
+			DefaultAudioTrackIndex int `json:"defaultAudioTrackIndex"`
 		} `json:"playerCaptionsTracklistRenderer"`
 	} `json:"captions"`
-	PlayabilityStatus struct {
-		Status string `json:"status"`
-		Reason string `json:"reason"`
-	} `json:"playabilityStatus"`
+	PlayabilityStatus PlayabilityStatus `json:"playabilityStatus"`
+	VideoDetails      VideoDetails      `json:"videoDetails"`
+	StreamingData     StreamingData     `json:"streamingData"`
+	Microformat       Microformat       `json:"microformat"`
+
+	// CaptionTracksPath is the dotted path at which the caption tracks were
+	// found, or empty if the response had none.
+	CaptionTracksPath string `json:"-"`
+
+	raw        []byte // The response body as received.
+	clientName string // Client profile the response was requested as.
+}
+
+// VideoDetails holds basic metadata about a video.
+type VideoDetails struct {
+	VideoID          string `json:"videoId"`
+	Title            string `json:"title"`
+	LengthSeconds    string `json:"lengthSeconds"`
+	ChannelID        string `json:"channelId"`
+	Author           string `json:"author"`
+	ShortDescription string `json:"shortDescription"`
+	IsLive           bool   `json:"isLive"`
+	IsUpcoming       bool   `json:"isUpcoming"`
+	IsLiveContent    bool   `json:"isLiveContent"` // Set for live broadcasts and their replays.
+
+	Keywords       []string   `json:"keywords"`
+	ViewCount      string     `json:"viewCount"`
+	Thumbnail      Thumbnails `json:"thumbnail"`
+	IsPrivate      bool       `json:"isPrivate"`
+	IsCrawlable    bool       `json:"isCrawlable"`
+	AllowRatings   bool       `json:"allowRatings"`
+	IsOwnerViewing bool       `json:"isOwnerViewing"`
+}
+
+// PlayabilityStatus describes whether a video can be played and why not.
+type PlayabilityStatus struct {
+	Status      string `json:"status"`
+	Reason      string `json:"reason"`
+	ErrorScreen struct {
+		PlayerErrorMessageRenderer struct {
+			Reason    FormattedText `json:"reason"`
+			Subreason FormattedText `json:"subreason"`
+		} `json:"playerErrorMessageRenderer"`
+	} `json:"errorScreen"`
 }
 
 // Transcript represents the structure of the final XML transcript file.
 type Transcript struct {
-	XMLName xml.Name `xml:"transcript"`
-	Texts   []Text   `xml:"text"`
+	XMLName      xml.Name   `xml:"transcript"`
+	Texts        []Text     `xml:"text"`
+	VideoID      string     `xml:"-"`
+	LanguageCode string     `xml:"-"`
+	Title        string     `xml:"-"`
+	Chapters     []Chapter  `xml:"-"`
+	Provenance   Provenance `xml:"-"`
+	Warnings     []string   `xml:"-"` // Problems noticed with the delivered captions.
 }
 
 // Text represents a single line of text in the transcript.
@@ -55,58 +159,314 @@ type Text struct {
 	Start    float64 `xml:"start,attr"`
 	Duration float64 `xml:"dur,attr"`
 	Content  string  `xml:",chardata"`
+	Speaker  string  `xml:"-"` // Set by ExtractSpeakers.
+	Source   string  `xml:"-"` // Video the segment came from, set by FillGaps.
+}
+
+// End returns the time in seconds at which the text stops being displayed.
+func (t Text) End() float64 {
+	return t.Start + t.Duration
 }
 
 // Regular expressions
 var (
-	apiKeyRegex  = regexp.MustCompile(`"INNERTUBE_API_KEY":"([^"]+)"`)
-	htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+	apiKeyRegex        = regexp.MustCompile(`"INNERTUBE_API_KEY":"([^"]+)"`)
+	clientVersionRegex = regexp.MustCompile(`"INNERTUBE_(?:CONTEXT_)?CLIENT_VERSION":"([^"]+)"`)
+	visitorDataRegex   = regexp.MustCompile(`"(?:VISITOR_DATA|visitorData)":"([^"]+)"`)
 )
 
 // Client is a client for fetching YouTube transcripts.
+//
+// A Client is safe for concurrent use by multiple goroutines and should be
+// shared rather than created per request, so that connections and cookies are
+// reused. Its configuration is fixed by New; the only state that changes
+// afterwards is the cookie jar, the cached InnerTube configuration, the
+// extraction statistics and the shape monitor, all of which synchronize
+// internally.
 type Client struct {
-	httpClient *http.Client
+	httpClient     *http.Client
+	transport      *http.Transport // Tuned by the connection options.
+	profiles       []ClientProfile
+	clientVersion  string
+	formatFallback bool
+	noAgeGate      bool // Disables the AgeGateProfiles fallback.
+	noTimedText    bool // Disables the timedtext list fallback.
+	noCaptionRetry bool // Disables the CaptionFallbackProfiles retry.
+	poToken        string
+	tokens         TokenSource // Signs player requests in when set.
+	shapeMonitor   *ShapeMonitor
+	logger         *slog.Logger
+	cache          *httpcache.Transport
+	recorder       *replay.Recorder
+	telemetry      Telemetry
+
+	configTTL     time.Duration
+	configMu      sync.Mutex
+	config        innertubeConfig
+	configFetched time.Time
+
+	statsMu            sync.Mutex
+	captionTracksPaths map[string]int
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithClientProfiles sets the InnerTube client profiles used for player
+// requests. They are tried in order until one returns a playable response.
+func WithClientProfiles(profiles ...ClientProfile) Option {
+	return func(c *Client) {
+		c.profiles = profiles
+	}
+}
+
+// WithClientVersion pins the clientVersion sent with the WEB profile instead of
+// using the version discovered on the watch page.
+func WithClientVersion(version string) Option {
+	return func(c *Client) {
+		c.clientVersion = version
+	}
+}
+
+// WithConfigTTL sets how long the API key, client version and visitor data
+// scraped from a watch page are reused for other videos before a watch page
+// is fetched again. A player request failing for any reason other than the
+// video itself also discards them. The default is one hour; zero or less
+// scrapes the watch page for every video.
+func WithConfigTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.configTTL = ttl
+	}
+}
+
+// WithFormatFallback makes the client fetch a track again in the srv3 format
+// when the default format comes back empty or as a single cue.
+func WithFormatFallback() Option {
+	return func(c *Client) {
+		c.formatFallback = true
+	}
+}
+
+// WithAgeGateFallback sets whether player requests for age-restricted videos
+// are retried with the embedded clients in AgeGateProfiles after every
+// configured profile hit the age check. It is enabled by default.
+func WithAgeGateFallback(enabled bool) Option {
+	return func(c *Client) {
+		c.noAgeGate = !enabled
+	}
+}
+
+// WithPOToken sets the proof-of-origin token sent with every player request.
+// YouTube increasingly flags requests without one as automated; the token has
+// to be generated outside this package, for example by a browser session with
+// the same visitor data.
+func WithPOToken(token string) Option {
+	return func(c *Client) {
+		c.poToken = token
+	}
+}
+
+// WithCaptionFallback sets whether a playable player response without caption
+// tracks is followed by requests with the remaining configured profiles and
+// then the CaptionFallbackProfiles, since some videos list their tracks to
+// some clients only. It is enabled by default; disabling it saves requests
+// for videos known to have no captions.
+func WithCaptionFallback(enabled bool) Option {
+	return func(c *Client) {
+		c.noCaptionRetry = !enabled
+	}
+}
+
+// WithTransport sets the RoundTripper used for every HTTP request, for example
+// to route requests through a proxy or to a fake server in tests.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithCache keeps watch pages and caption tracks in an on-disk cache and
+// revalidates them with ETag and Last-Modified instead of downloading them
+// again. Within the cache's TTL, player requests are answered from it too,
+// so a repeated run makes no requests at all. The cache's Base is set to
+// the client's transport, so it composes with WithTransport and the
+// connection options.
+func WithCache(cache *httpcache.Transport) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithRecorder records every response the client receives, after caching,
+// so the run can be repeated later by passing a replay.Replayer to
+// WithTransport. The recorder's Base is set to the client's transport.
+func WithRecorder(recorder *replay.Recorder) Option {
+	return func(c *Client) {
+		c.recorder = recorder
+	}
+}
+
+// WithLogger sets the logger the client reports its progress to: each watch
+// page fetch, InnerTube call, track selection and caption fetch is logged at
+// debug level with its duration, and fallbacks and retries at info level.
+// Nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
 }
 
 // New creates a new Client.
-func New() (*Client, error) {
+func New(opts ...Option) (*Client, error) {
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
 	}
-	return &Client{
-		httpClient: &http.Client{Jar: jar},
-	}, nil
+	transport := newTransport()
+	c := &Client{
+		httpClient: &http.Client{Jar: jar, Transport: transport},
+		transport:  transport,
+		profiles:   DefaultProfiles,
+		logger:     slog.New(slog.DiscardHandler),
+		configTTL:  defaultConfigTTL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.profiles) == 0 {
+		return nil, fmt.Errorf("at least one client profile is required")
+	}
+	if c.cache != nil {
+		c.cache.Base = c.httpClient.Transport
+		if c.cache.CachePost == nil {
+			c.cache.CachePost = isPlayerRequest
+		}
+		c.httpClient.Transport = c.cache
+	}
+	if c.recorder != nil {
+		c.recorder.Base = c.httpClient.Transport
+		c.httpClient.Transport = c.recorder
+	}
+	return c, nil
 }
 
 // ListTranscripts fetches and returns the available transcript tracks for a given video ID.
-func (c *Client) ListTranscripts(videoID string) ([]CaptionTrack, error) {
-	playerResponse, err := c.getPlayerResponse(videoID)
+func (c *Client) ListTranscripts(videoID string, opts ...CallOption) ([]CaptionTrack, error) {
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, newCallConfig(opts))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get player response: %w", err)
+		err = fmt.Errorf("failed to get player response: %w", err)
+		var ok bool
+		if playerResponse, ok = c.timedTextFallback(context.Background(), videoID, err); !ok {
+			return nil, err
+		}
 	}
 	return playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks, nil
 }
 
-// GetTranscript fetches the transcript for a given video ID and language code.
-// If languageCode is empty, it will fetch the first available transcript.
-func (c *Client) GetTranscript(videoID string, languageCode string) (*Transcript, error) {
-	tracks, err := c.ListTranscripts(videoID)
+// GetPlayerResponseRaw returns the undecoded InnerTube player response for a
+// video, for callers needing fields this package does not model. The watch
+// page scraping, client profile fallback and playability checks are the same
+// as for the other methods.
+func (c *Client) GetPlayerResponseRaw(videoID string, opts ...CallOption) (json.RawMessage, error) {
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, newCallConfig(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player response: %w", err)
+	}
+	return json.RawMessage(playerResponse.raw), nil
+}
+
+// ListTranslationLanguages returns every language the video's translatable
+// caption tracks can be machine-translated into.
+func (c *Client) ListTranslationLanguages(videoID string, opts ...CallOption) ([]TranslationLanguage, error) {
+	playerResponse, err := c.getPlayerResponse(context.Background(), videoID, newCallConfig(opts))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list transcripts: %w", err)
+		return nil, fmt.Errorf("failed to get player response: %w", err)
+	}
+	return playerResponse.Captions.PlayerCaptionsTracklistRenderer.TranslationLanguages, nil
+}
+
+// GetTranscript fetches the transcript for a given video ID and language code.
+// If languageCode is empty, it will fetch the first available transcript. If
+// there is no transcript in that language, the call fails unless
+// WithLanguagePolicy allows a substitute.
+func (c *Client) GetTranscript(videoID string, languageCode string, opts ...CallOption) (*Transcript, error) {
+	return c.getTranscript(context.Background(), videoID, languageCode, newCallConfig(opts))
+}
+
+// getTranscript fetches the transcript for languageCode, applying the call's
+// language policy.
+func (c *Client) getTranscript(ctx context.Context, videoID, languageCode string, call callConfig) (*Transcript, error) {
+	playerResponse, playerErr := c.getPlayerResponse(ctx, videoID, call)
+	if playerErr != nil {
+		playerErr = fmt.Errorf("failed to get player response: %w", playerErr)
+		var ok bool
+		if playerResponse, ok = c.timedTextFallback(ctx, videoID, playerErr); !ok {
+			return c.getAlternateTranscript(ctx, videoID, languageCode, call, playerErr)
+		}
 	}
+	tracks := playerResponse.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks
+	videoType := liveVideoType(playerResponse)
 
 	if len(tracks) == 0 {
+		if videoType == VideoLiveReplay {
+			return nil, fmt.Errorf("no transcripts available for this live stream replay yet; its captions are generated after the broadcast is processed")
+		}
 		return nil, fmt.Errorf("no transcripts available for this video")
 	}
 
-	targetTrack, err := findTrack(tracks, languageCode)
+	targetTrack, substitution, err := selectTrack(playerResponse, languageCode, call.languagePolicy)
 	if err != nil {
 		return nil, err
 	}
 
-	transcriptXML, err := c.fetchURL(targetTrack.BaseURL)
+	transcript, err := c.fetchVideoTranscript(ctx, videoID, playerResponse, targetTrack, call)
 	if err != nil {
+		return nil, err
+	}
+	recordSubstitution(transcript, languageCode, substitution)
+	if playerErr != nil {
+		transcript.Warnings = append(transcript.Warnings, fmt.Sprintf(
+			"fetched from the timedtext list without video details because the player request failed: %v", playerErr))
+	}
+	if videoType == VideoLive {
+		transcript.Warnings = append(transcript.Warnings,
+			"the broadcast is still live, so the transcript only covers what has been captioned so far; use TailLiveTranscript to follow it")
+	}
+	return transcript, nil
+}
+
+// fetchVideoTranscript fetches a track and fills in the video metadata taken
+// from the player response.
+func (c *Client) fetchVideoTranscript(ctx context.Context, videoID string, playerResponse *PlayerResponse, track CaptionTrack, call callConfig) (*Transcript, error) {
+	c.logger.Debug("selected caption track", "video_id", videoID, "language", track.LanguageCode,
+		"kind", track.Kind, "vss_id", track.VssID, "translated_from", track.translatedFrom)
+	transcript, err := c.fetchTranscript(ctx, track, call.clean)
+	if err != nil {
+		c.report(StageCaptions, "timedtext", err)
+		return nil, err
+	}
+	if transcript, err = c.checkShape(ctx, track, transcript, call.clean); err != nil {
+		c.report(StageCaptions, "timedtext", err)
+		return nil, err
+	}
+	c.report(StageCaptions, "timedtext", nil)
+	applyCleanOptions(transcript, call.clean)
+	if err := checkLanguage(transcript, call.strictLanguage); err != nil {
+		return nil, err
+	}
+	transcript.VideoID = videoID
+	transcript.Title = playerResponse.VideoDetails.Title
+	transcript.Chapters = ParseChapters(playerResponse.VideoDetails.ShortDescription)
+	transcript.Provenance = newProvenance(videoID, track)
+	transcript.Provenance.Client = playerResponse.clientName
+	return transcript, nil
+}
+
+func (c *Client) fetchTranscript(ctx context.Context, track CaptionTrack, clean CleanOptions) (*Transcript, error) {
+	started := time.Now()
+	transcriptXML, err := c.fetchURLContext(ctx, track.BaseURL)
+	if err != nil {
+		c.logger.Debug("caption fetch failed", "language", track.LanguageCode, "duration", time.Since(started), "error", err)
 		return nil, fmt.Errorf("failed to fetch transcript xml: %w", err)
 	}
 
@@ -115,7 +475,11 @@ func (c *Client) GetTranscript(videoID string, languageCode string) (*Transcript
 		return nil, fmt.Errorf("failed to unmarshal transcript xml: %w", err)
 	}
 
-	cleanTranscript(&transcript)
+	c.logger.Debug("fetched captions", "language", track.LanguageCode, "segments", len(transcript.Texts),
+		"duration", time.Since(started))
+
+	cleanTranscript(&transcript, clean)
+	transcript.LanguageCode = track.LanguageCode
 	return &transcript, nil
 }
 
@@ -123,34 +487,161 @@ func findTrack(tracks []CaptionTrack, languageCode string) (CaptionTrack, error)
 	if languageCode == "" {
 		return tracks[0], nil
 	}
-	for _, track := range tracks {
-		if track.LanguageCode == languageCode {
-			return track, nil
-		}
+	if track, ok := MatchLanguage(tracks, languageCode); ok {
+		return track, nil
 	}
 	return CaptionTrack{}, fmt.Errorf("transcript for language '%s' not found", languageCode)
 }
 
-func cleanTranscript(transcript *Transcript) {
-	for i := range transcript.Texts {
-		cleanText := html.UnescapeString(transcript.Texts[i].Content)
-		cleanText = htmlTagRegex.ReplaceAllString(cleanText, "")
-		transcript.Texts[i].Content = strings.TrimSpace(cleanText)
+// isPlayerRequest reports whether req is an InnerTube player request, which
+// only reads the video's metadata and so may be cached.
+func isPlayerRequest(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.String(), strings.TrimSuffix(innertubeAPIURL, "?key="))
+}
+
+func (c *Client) getPlayerResponse(ctx context.Context, videoID string, call callConfig) (*PlayerResponse, error) {
+	if config, ok := c.cachedConfig(); ok {
+		playerResponse, err := c.fetchPlayerResponse(ctx, videoID, config, call)
+		if !isStaleConfig(err) {
+			return playerResponse, err
+		}
+		c.logger.Info("player request failed with cached innertube config, scraping watch page again",
+			"video_id", videoID, "error", err)
+		c.forgetConfig(config)
+	}
+
+	config, err := c.scrapeConfig(ctx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return c.fetchPlayerResponse(ctx, videoID, config, call)
+}
+
+// scrapeConfig fetches the watch page of videoID and extracts the InnerTube
+// configuration from it, caching it for other videos.
+func (c *Client) scrapeConfig(ctx context.Context, videoID string) (innertubeConfig, error) {
+	started := time.Now()
+	htmlContent, err := c.fetchWatchPage(ctx, videoID)
+	if err != nil {
+		c.logger.Debug("watch page fetch failed", "video_id", videoID, "duration", time.Since(started), "error", err)
+		return innertubeConfig{}, fmt.Errorf("failed to fetch video page: %w", err)
 	}
+	c.logger.Debug("fetched watch page", "video_id", videoID, "bytes", len(htmlContent), "duration", time.Since(started))
+
+	config, err := extractInnertubeConfig(htmlContent)
+	c.report(StageInnertubeConfig, "watch_page", err)
+	if err != nil {
+		c.logger.Debug("innertube config extraction failed", "video_id", videoID, "error", err)
+		return innertubeConfig{}, err
+	}
+	c.logger.Debug("extracted innertube config", "video_id", videoID, "client_version", config.clientVersion,
+		"visitor_data", config.visitorData != "")
+
+	if c.configTTL > 0 {
+		c.configMu.Lock()
+		c.config, c.configFetched = config, time.Now()
+		c.configMu.Unlock()
+	}
+	return config, nil
 }
 
-func (c *Client) getPlayerResponse(videoID string) (*PlayerResponse, error) {
-	htmlContent, err := c.fetchURL(watchURL + videoID)
+// cachedConfig returns the cached InnerTube configuration if it has not
+// expired.
+func (c *Client) cachedConfig() (innertubeConfig, bool) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	if c.config.apiKey == "" || time.Since(c.configFetched) >= c.configTTL {
+		return innertubeConfig{}, false
+	}
+	return c.config, true
+}
+
+// forgetConfig drops the cached configuration unless another request has
+// already replaced it.
+func (c *Client) forgetConfig(config innertubeConfig) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	if c.config == config {
+		c.config = innertubeConfig{}
+	}
+}
+
+// isStaleConfig reports whether a player request failure may be caused by an
+// outdated configuration rather than by the video: anything but a playability
+// status, and bot checks, which fresh visitor data can avoid.
+func isStaleConfig(err error) bool {
+	if err == nil {
+		return false
+	}
+	var playabilityErr *PlayabilityError
+	return !errors.As(err, &playabilityErr) || playabilityErr.isBotCheck()
+}
+
+// fetchWatchPage fetches the watch page, accepting the EU cookie consent
+// interstitial once if YouTube serves it instead of the video page.
+func (c *Client) fetchWatchPage(ctx context.Context, videoID string) (string, error) {
+	htmlContent, err := c.fetchURLContext(ctx, watchURL+videoID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch video page: %w", err)
+		return "", err
+	}
+	if !isConsentPage(htmlContent) {
+		return htmlContent, nil
 	}
 
-	apiKey, err := extractAPIKey(htmlContent)
+	c.logger.Info("accepting cookie consent and retrying watch page", "video_id", videoID)
+	if err := c.acceptConsent(); err != nil {
+		return "", err
+	}
+	htmlContent, err = c.fetchURLContext(ctx, watchURL+videoID)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if isConsentPage(htmlContent) {
+		return "", ErrConsentRequired
 	}
+	return htmlContent, nil
+}
 
-	return c.fetchPlayerResponse(videoID, apiKey)
+// isConsentPage reports whether the page is the consent interstitial rather
+// than a watch page.
+func isConsentPage(htmlContent string) bool {
+	return strings.Contains(htmlContent, consentHost) && !apiKeyRegex.MatchString(htmlContent)
+}
+
+// acceptConsent stores the cookies YouTube sets once consent has been given.
+func (c *Client) acceptConsent() error {
+	if c.httpClient.Jar == nil {
+		return ErrConsentRequired
+	}
+	u, err := url.Parse(youtubeURL)
+	if err != nil {
+		return err
+	}
+	c.httpClient.Jar.SetCookies(u, []*http.Cookie{
+		{Name: "CONSENT", Value: "YES+cb", Domain: ".youtube.com", Path: "/"},
+		{Name: "SOCS", Value: "CAI", Domain: ".youtube.com", Path: "/"},
+	})
+	return nil
+}
+
+// innertubeConfig holds the values scraped from the watch page that are
+// needed to call the InnerTube API.
+type innertubeConfig struct {
+	apiKey        string
+	clientVersion string // Empty if the page did not expose one.
+	visitorData   string // Identifies the visitor session; empty if not exposed.
+}
+
+func extractInnertubeConfig(htmlContent string) (innertubeConfig, error) {
+	apiKey, err := extractAPIKey(htmlContent)
+	if err != nil {
+		return innertubeConfig{}, err
+	}
+	return innertubeConfig{
+		apiKey:        apiKey,
+		clientVersion: extractClientVersion(htmlContent),
+		visitorData:   extractVisitorData(htmlContent),
+	}, nil
 }
 
 func extractAPIKey(htmlContent string) (string, error) {
@@ -161,29 +652,170 @@ func extractAPIKey(htmlContent string) (string, error) {
 	return matches[1], nil
 }
 
-func (c *Client) fetchPlayerResponse(videoID, apiKey string) (*PlayerResponse, error) {
+func extractClientVersion(htmlContent string) string {
+	matches := clientVersionRegex.FindStringSubmatch(htmlContent)
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+// extractVisitorData returns the visitor data the watch page was served
+// with, unescaped, or "" if the page did not expose any.
+func extractVisitorData(htmlContent string) string {
+	matches := visitorDataRegex.FindStringSubmatch(htmlContent)
+	if len(matches) < 2 {
+		return ""
+	}
+	visitorData, err := url.QueryUnescape(matches[1])
+	if err != nil {
+		return matches[1]
+	}
+	return visitorData
+}
+
+// fetchPlayerResponse requests the player response with each configured
+// client profile in turn, returning the first playable one with caption
+// tracks. If the playable responses have none, the CaptionFallbackProfiles
+// not configured are tried too before the first playable response is
+// returned.
+func (c *Client) fetchPlayerResponse(ctx context.Context, videoID string, config innertubeConfig, call callConfig) (*PlayerResponse, error) {
+	var lastErr error
+	var captionless *PlayerResponse
+	ageRestricted := false
+	for _, profile := range c.profiles {
+		if profile.Name == ProfileWeb.Name {
+			profile.Version = c.webClientVersion(profile, config)
+		}
+		started := time.Now()
+		playerResponse, err := c.fetchPlayerResponseAs(ctx, videoID, config, profile, call)
+		c.report(StagePlayerResponse, profile.Name, err)
+		if err == nil {
+			c.logger.Debug("fetched player response", "video_id", videoID, "client", profile.Name,
+				"client_version", profile.Version, "duration", time.Since(started))
+			if playerResponse.hasCaptionTracks() || c.noCaptionRetry {
+				return playerResponse, nil
+			}
+			c.logger.Info("player response has no caption tracks, trying next client profile", "video_id", videoID,
+				"client", profile.Name)
+			if captionless == nil {
+				captionless = playerResponse
+			}
+			continue
+		}
+		c.logger.Info("player request failed, trying next client profile", "video_id", videoID,
+			"client", profile.Name, "duration", time.Since(started), "error", err)
+		lastErr = fmt.Errorf("%s client: %w", profile.Name, err)
+		ageRestricted = ageRestricted || errors.Is(err, ErrAgeCheckRequired)
+	}
+	if captionless != nil {
+		if playerResponse := c.retryForCaptions(ctx, videoID, config, call); playerResponse != nil {
+			return playerResponse, nil
+		}
+		return captionless, nil
+	}
+	if !ageRestricted || c.noAgeGate {
+		return nil, lastErr
+	}
+
+	for _, profile := range AgeGateProfiles {
+		c.logger.Info("video is age-restricted, trying embedded client", "video_id", videoID, "client", profile.Name)
+		playerResponse, err := c.fetchPlayerResponseAs(ctx, videoID, config, profile, call)
+		c.report(StagePlayerResponse, profile.Name, err)
+		if err == nil {
+			return playerResponse, nil
+		}
+		c.logger.Info("embedded client failed", "video_id", videoID, "client", profile.Name, "error", err)
+	}
+	return nil, lastErr
+}
+
+// retryForCaptions requests the player response with the
+// CaptionFallbackProfiles that are not configured, returning the first with
+// caption tracks or nil.
+func (c *Client) retryForCaptions(ctx context.Context, videoID string, config innertubeConfig, call callConfig) *PlayerResponse {
+	for _, profile := range CaptionFallbackProfiles {
+		if slices.ContainsFunc(c.profiles, func(p ClientProfile) bool { return p.Name == profile.Name }) {
+			continue
+		}
+		c.logger.Info("no caption tracks from configured clients, trying fallback client", "video_id", videoID,
+			"client", profile.Name)
+		playerResponse, err := c.fetchPlayerResponseAs(ctx, videoID, config, profile, call)
+		c.report(StagePlayerResponse, profile.Name, err)
+		if err == nil && playerResponse.hasCaptionTracks() {
+			return playerResponse
+		}
+	}
+	return nil
+}
+
+// hasCaptionTracks reports whether the response lists any caption track.
+func (r *PlayerResponse) hasCaptionTracks() bool {
+	return len(r.Captions.PlayerCaptionsTracklistRenderer.CaptionTracks) > 0
+}
+
+// webClientVersion picks the WEB clientVersion: the pinned version if set,
+// otherwise the one discovered on the watch page, otherwise the profile's own.
+func (c *Client) webClientVersion(profile ClientProfile, config innertubeConfig) string {
+	if c.clientVersion != "" {
+		return c.clientVersion
+	}
+	if config.clientVersion != "" {
+		return config.clientVersion
+	}
+	return profile.Version
+}
+
+func (c *Client) fetchPlayerResponseAs(ctx context.Context, videoID string, config innertubeConfig, profile ClientProfile, call callConfig) (*PlayerResponse, error) {
+	clientContext := map[string]interface{}{
+		"clientName":    profile.Name,
+		"clientVersion": profile.Version,
+		"hl":            "en",
+		"gl":            call.country,
+	}
+	if config.visitorData != "" {
+		clientContext["visitorData"] = config.visitorData
+	}
+	for key, value := range profile.Extra {
+		clientContext[key] = value
+	}
+	innertubeContext := map[string]interface{}{
+		"client": clientContext,
+	}
+	if profile.EmbedURL != "" {
+		innertubeContext["thirdParty"] = map[string]interface{}{"embedUrl": profile.EmbedURL}
+	}
 	innertubePayload := map[string]interface{}{
-		"context": map[string]interface{}{
-			"client": map[string]interface{}{
-				"clientName":    "WEB",
-				"clientVersion": "2.20210721.00.00",
-				"hl":            "en",
-				"gl":            "US",
-			},
-		},
+		"context": innertubeContext,
 		"videoId": videoID,
 	}
+	if c.poToken != "" {
+		innertubePayload["serviceIntegrityDimensions"] = map[string]interface{}{"poToken": c.poToken}
+	}
 
 	payloadBytes, err := json.Marshal(innertubePayload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal innertube payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", innertubeAPIURL+apiKey, bytes.NewBuffer(payloadBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, innertubeAPIURL+config.apiKey, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create innertube request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if profile.UserAgent != "" {
+		req.Header.Set("User-Agent", profile.UserAgent)
+	}
+	if config.visitorData != "" {
+		req.Header.Set("X-Goog-Visitor-Id", config.visitorData)
+	}
+	if c.tokens != nil {
+		token, err := c.tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get oauth token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -191,20 +823,47 @@ func (c *Client) fetchPlayerResponse(videoID, apiKey string) (*PlayerResponse, e
 	}
 	defer resp.Body.Close()
 
-	var playerResponse PlayerResponse
-	if err := json.NewDecoder(resp.Body).Decode(&playerResponse); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read player response: %w", err)
+	}
+
+	playerResponse, err := decodePlayerResponse(body)
+	if err != nil {
 		return nil, fmt.Errorf("failed to decode player response: %w", err)
 	}
+	playerResponse.raw = body
+	playerResponse.clientName = profile.Name
+	c.recordCaptionTracksPath(playerResponse.CaptionTracksPath)
+	if playerResponse.CaptionTracksPath != "" {
+		c.report(StageCaptionTracks, playerResponse.CaptionTracksPath, nil)
+	} else {
+		c.report(StageCaptionTracks, noCaptionTracksPath, errNoCaptionTracks)
+	}
 
 	if playerResponse.PlayabilityStatus.Status != "OK" {
-		return nil, fmt.Errorf("video not playable: %s", playerResponse.PlayabilityStatus.Reason)
+		return nil, newPlayabilityError(playerResponse)
 	}
 
-	return &playerResponse, nil
+	if c.shapeMonitor != nil {
+		// Monitoring is advisory and must never fail a fetch.
+		_ = c.shapeMonitor.observe(body)
+	}
+
+	return playerResponse, nil
 }
 
 func (c *Client) fetchURL(url string) (string, error) {
-	resp, err := c.httpClient.Get(url)
+	return c.fetchURLContext(context.Background(), url)
+}
+
+func (c *Client) fetchURLContext(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,346 @@
+// Package yttranscripttest provides a fake YouTube server and recorded
+// responses for testing code that uses the yttranscript package offline.
+package yttranscripttest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"yt-transcript/yttranscript"
+)
+
+// FixtureVideoID is the video served by a new Server.
+const FixtureVideoID = "dQw4w9WgXcQ"
+
+// FixtureChannelID is the channel of FixtureVideoID, whose feed lists it.
+const FixtureChannelID = "UCuAXFkgsw1L7xaCfnd5JJOw"
+
+// FixtureChannelHandle is the handle of FixtureChannelID, whose channel
+// search finds FixtureVideoID.
+const FixtureChannelHandle = "@RickAstleyYT"
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// Fixture returns the contents of a recorded response shipped with this
+// package: "watch.html", "player.json", "timedtext_en.xml" or
+// "timedtext_en_de.xml" (the English track machine-translated into German).
+func Fixture(name string) []byte {
+	data, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic("yttranscripttest: unknown fixture " + name)
+	}
+	return data
+}
+
+// Server is a fake YouTube server answering watch page, InnerTube player,
+// timedtext, timedtext list and channel feed requests from recorded
+// responses.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	watchPage []byte
+	players   map[string][]byte // Keyed by video ID, or by playerKey for one client.
+	timedText map[string][]byte // Keyed by timedTextKey.
+	feeds     map[string][]byte
+	shorts    map[string]bool
+	videos    map[string][]yttranscript.FeedEntry // Channel search results by handle or channel ID.
+}
+
+// NewServer starts a Server preloaded with the fixtures for FixtureVideoID.
+// Callers should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		watchPage: Fixture("watch.html"),
+		players:   make(map[string][]byte),
+		timedText: make(map[string][]byte),
+		feeds:     make(map[string][]byte),
+		shorts:    make(map[string]bool),
+		videos:    make(map[string][]yttranscript.FeedEntry),
+	}
+	s.SetPlayerResponse(FixtureVideoID, Fixture("player.json"))
+	s.SetTimedText(FixtureVideoID, "en", Fixture("timedtext_en.xml"))
+	s.SetTranslatedTimedText(FixtureVideoID, "en", "de", Fixture("timedtext_en_de.xml"))
+	s.SetChannelFeed(FixtureChannelID, Fixture("feed.xml"))
+	fixtureVideos := []yttranscript.FeedEntry{{
+		VideoID:   FixtureVideoID,
+		ChannelID: FixtureChannelID,
+		Title:     "Rick Astley - Never Gonna Give You Up (Official Music Video)",
+	}}
+	s.SetChannelVideos(FixtureChannelHandle, fixtureVideos)
+	s.SetChannelVideos(FixtureChannelID, fixtureVideos)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", s.handleWatch)
+	mux.HandleFunc("/youtubei/v1/player", s.handlePlayer)
+	mux.HandleFunc("/api/timedtext", s.handleTimedText)
+	mux.HandleFunc("/timedtext", s.handleLegacyTimedText)
+	mux.HandleFunc("/feeds/videos.xml", s.handleFeed)
+	mux.HandleFunc("/shorts/", s.handleShorts)
+	mux.HandleFunc("/", s.handleChannelSearch)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// SetWatchPage replaces the HTML returned for every watch page.
+func (s *Server) SetWatchPage(body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watchPage = body
+}
+
+// SetPlayerResponse sets the InnerTube player response returned for videoID.
+func (s *Server) SetPlayerResponse(videoID string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.players[videoID] = body
+}
+
+// SetPlayerResponseFor sets the InnerTube player response returned for
+// videoID to requests made as the named client, such as "WEB". It takes
+// precedence over SetPlayerResponse, which allows testing client fallback.
+func (s *Server) SetPlayerResponseFor(videoID, clientName string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.players[playerKey(videoID, clientName)] = body
+}
+
+func playerKey(videoID, clientName string) string {
+	return videoID + "/" + clientName
+}
+
+// SetTimedText sets the transcript XML returned for videoID in lang.
+func (s *Server) SetTimedText(videoID, lang string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timedText[timedTextKey(videoID, lang, "")] = body
+}
+
+// SetTranslatedTimedText sets the transcript XML returned for videoID in lang
+// machine-translated into tlang.
+func (s *Server) SetTranslatedTimedText(videoID, lang, tlang string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timedText[timedTextKey(videoID, lang, tlang)] = body
+}
+
+// SetChannelFeed sets the Atom feed returned for channelID.
+func (s *Server) SetChannelFeed(channelID string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feeds[channelID] = body
+}
+
+// SetChannelVideos sets the videos a channel search returns for a handle
+// (with the @) or channel ID. Every search returns all of them, in order,
+// whatever the query.
+func (s *Server) SetChannelVideos(channel string, videos []yttranscript.FeedEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.videos[channel] = videos
+}
+
+// SetShort marks videoID as a Short, so that its Shorts URL is served
+// instead of redirecting to the watch page.
+func (s *Server) SetShort(videoID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.shorts[videoID] = true
+}
+
+func timedTextKey(videoID, lang, tlang string) string {
+	return videoID + "/" + lang + "/" + tlang
+}
+
+// Transport returns a RoundTripper that sends every request to the server,
+// whatever host it was addressed to.
+func (s *Server) Transport() http.RoundTripper {
+	return s.TransportWith(http.DefaultTransport)
+}
+
+// TransportWith is like Transport but sends the requests through base, for
+// example a transport tuned for connection pooling.
+func (s *Server) TransportWith(base http.RoundTripper) http.RoundTripper {
+	target, _ := url.Parse(s.URL)
+	return &rewriteTransport{target: target, base: base}
+}
+
+// NewClient creates a yttranscript.Client whose requests go to the server.
+func (s *Server) NewClient(opts ...yttranscript.Option) (*yttranscript.Client, error) {
+	opts = append(opts, yttranscript.WithTransport(s.Transport()))
+	return yttranscript.New(opts...)
+}
+
+func (s *Server) handleWatch(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	body := s.watchPage
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(body)
+}
+
+func (s *Server) handlePlayer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var payload struct {
+		VideoID string `json:"videoId"`
+		Context struct {
+			Client struct {
+				ClientName string `json:"clientName"`
+			} `json:"client"`
+		} `json:"context"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	body, ok := s.players[playerKey(payload.VideoID, payload.Context.Client.ClientName)]
+	if !ok {
+		body, ok = s.players[payload.VideoID]
+	}
+	s.mu.Unlock()
+	if !ok {
+		body = []byte(`{"playabilityStatus":{"status":"ERROR","reason":"This video is unavailable"}}`)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func (s *Server) handleTimedText(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	key := timedTextKey(query.Get("v"), query.Get("lang"), query.Get("tlang"))
+
+	s.mu.Lock()
+	body, ok := s.timedText[key]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.Write(body)
+}
+
+// handleLegacyTimedText serves the legacy timedtext endpoint, which lists the
+// tracks set with SetTimedText for type=list and otherwise serves them like
+// handleTimedText.
+func (s *Server) handleLegacyTimedText(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("type") != "list" {
+		s.handleTimedText(w, r)
+		return
+	}
+
+	prefix := query.Get("v") + "/"
+	var languages []string
+	s.mu.Lock()
+	for key := range s.timedText {
+		if rest, ok := strings.CutPrefix(key, prefix); ok && strings.HasSuffix(rest, "/") {
+			languages = append(languages, strings.TrimSuffix(rest, "/"))
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(languages)
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	fmt.Fprint(w, `<?xml version="1.0" encoding="utf-8" ?><transcript_list>`)
+	for i, lang := range languages {
+		fmt.Fprintf(w, `<track id="%d" name="" lang_code="%s" lang_original="%s" lang_default="%t"/>`,
+			i, html.EscapeString(lang), html.EscapeString(lang), i == 0)
+	}
+	fmt.Fprint(w, `</transcript_list>`)
+}
+
+// handleChannelSearch serves /@handle/search and /channel/ID/search with the
+// videos set by SetChannelVideos, embedded in the page as initial data.
+func (s *Server) handleChannelSearch(w http.ResponseWriter, r *http.Request) {
+	channel, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/"), "/search")
+	channel = strings.TrimPrefix(channel, "channel/")
+	s.mu.Lock()
+	videos, known := s.videos[channel]
+	s.mu.Unlock()
+	if !ok || !known {
+		http.NotFound(w, r)
+		return
+	}
+
+	type run struct {
+		Text string `json:"text"`
+	}
+	type videoRenderer struct {
+		VideoID string `json:"videoId"`
+		Title   struct {
+			Runs []run `json:"runs"`
+		} `json:"title"`
+	}
+	var contents []map[string]videoRenderer
+	for _, video := range videos {
+		renderer := videoRenderer{VideoID: video.VideoID}
+		renderer.Title.Runs = []run{{Text: video.Title}}
+		contents = append(contents, map[string]videoRenderer{"videoRenderer": renderer})
+	}
+	data, err := json.Marshal(map[string]interface{}{"contents": contents})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "<html><body><script>var ytInitialData = %s;</script></body></html>", data)
+}
+
+func (s *Server) handleFeed(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	body, ok := s.feeds[r.URL.Query().Get("channel_id")]
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	w.Write(body)
+}
+
+func (s *Server) handleShorts(w http.ResponseWriter, r *http.Request) {
+	videoID := strings.TrimPrefix(r.URL.Path, "/shorts/")
+
+	s.mu.Lock()
+	short, body := s.shorts[videoID], s.watchPage
+	s.mu.Unlock()
+	if !short {
+		http.Redirect(w, r, "/watch?v="+videoID, http.StatusSeeOther)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(body)
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return t.base.RoundTrip(req)
+}